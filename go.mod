@@ -1,3 +1,3 @@
 module github.com/henrylee2cn/option
 
-go 1.18
+go 1.23.0
@@ -0,0 +1,61 @@
+package option
+
+import (
+	"container/list"
+)
+
+// LRU is a least-recently-used cache whose lookups return [`Option`].
+// It is not safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	capacity int
+	list     *list.List
+	items    map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU creates a LRU cache holding at most `capacity` entries.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		list:     list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value of `key` and marks it as recently used,
+// or returns [`None`] if it is absent.
+func (c *LRU[K, V]) Get(key K) Option[V] {
+	if e, ok := c.items[key]; ok {
+		c.list.MoveToFront(e)
+		return Some(e.Value.(*lruEntry[K, V]).value)
+	}
+	return None[V]()
+}
+
+// Put sets the value of `key` and marks it as recently used,
+// evicting the least recently used entry if the cache is full.
+func (c *LRU[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.list.MoveToFront(e)
+		return
+	}
+	if c.capacity <= 0 {
+		return
+	}
+	if c.list.Len() >= c.capacity {
+		var oldest = c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+	c.items[key] = c.list.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	return c.list.Len()
+}
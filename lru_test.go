@@ -0,0 +1,20 @@
+package option
+
+import (
+	"fmt"
+)
+
+func ExampleLRU() {
+	var c = NewLRU[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	fmt.Println(c.Get("a"), c.Get("x"))
+
+	// "b" is the least recently used one.
+	c.Put("c", 3)
+	fmt.Println(c.Get("a"), c.Get("b"), c.Get("c"), c.Len())
+
+	// Output:
+	// Some(1) None
+	// Some(1) None Some(3) 2
+}
@@ -1,7 +1,29 @@
 package option
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // Option represents an optional value:
@@ -23,7 +45,8 @@ func Wrap[T any](value *T) Option[T] {
 	return Option[T]{value: value}
 }
 
-// Some wraps a nonnull value.
+// Some wraps a nonnull value by storing the address of its copy,
+// so the option is [`Some`] even if `value` is the zero value.
 func Some[T any](value T) Option[T] {
 	return Option[T]{value: &value}
 }
@@ -33,6 +56,20 @@ func None[T any]() Option[T] {
 	return Option[T]{value: nil}
 }
 
+// FromPtr returns [`None`] if `p` is nil, otherwise returns [`Some`] of the pointed-to value.
+// It is the same as [`Wrap`], since a nil pointer is always [`None`].
+func FromPtr[T any](p *T) Option[T] {
+	return Wrap(p)
+}
+
+// FromTuple returns [`Some`] of the pointed-to value if `ok` is `true`, otherwise returns [`None`].
+func FromTuple[T any](p *T, ok bool) Option[T] {
+	if ok {
+		return Wrap(p)
+	}
+	return None[T]()
+}
+
 // ToOptnil converts to Optnil[T].
 func (o Option[T]) ToOptnil() Optnil[T] {
 	return Ptr[T](o.value)
@@ -65,7 +102,7 @@ func (o Option[T]) Expect(msg string) T {
 	return *o.value
 }
 
-// Unwrap returns the contained value.
+// Unwrap returns a copy of the contained value.
 // Panics if the value is null.
 func (o Option[T]) Unwrap() T {
 	if o.IsSome() {
@@ -75,7 +112,7 @@ func (o Option[T]) Unwrap() T {
 	panic(fmt.Sprintf("call Option[%T].Unwrap() on nonnull", t))
 }
 
-// UnwrapOr returns the contained value or a provided default.
+// UnwrapOr returns a copy of the contained value or a provided default.
 func (o Option[T]) UnwrapOr(defaultSome T) T {
 	if o.IsSome() {
 		return *o.value
@@ -83,7 +120,7 @@ func (o Option[T]) UnwrapOr(defaultSome T) T {
 	return defaultSome
 }
 
-// UnwrapOrElse returns the contained value or computes it from a closure.
+// UnwrapOrElse returns a copy of the contained value or computes it from a closure.
 func (o Option[T]) UnwrapOrElse(defaultSome func() T) T {
 	if o.IsSome() {
 		return *o.value
@@ -91,6 +128,15 @@ func (o Option[T]) UnwrapOrElse(defaultSome func() T) T {
 	return defaultSome()
 }
 
+// UnwrapOrZero returns a copy of the contained value or the zero value of `T`.
+func (o Option[T]) UnwrapOrZero() T {
+	if o.IsSome() {
+		return *o.value
+	}
+	var zero T
+	return zero
+}
+
 // UnwrapUnchecked returns the contained value.
 func (o Option[T]) UnwrapUnchecked() T {
 	return *o.value
@@ -215,8 +261,8 @@ func (o Option[T]) OrElse(f func() Option[T]) Option[T] {
 	return o
 }
 
-// XorElse [`Some`] if exactly one of `self`, `optb` is [`Some`], otherwise returns [`None`].
-func (o Option[T]) XorElse(optb Option[T]) Option[T] {
+// Xor returns [`Some`] if exactly one of `self`, `optb` is [`Some`], otherwise returns [`None`].
+func (o Option[T]) Xor(optb Option[T]) Option[T] {
 	if o.IsSome() && optb.IsNone() {
 		return o
 	}
@@ -226,6 +272,12 @@ func (o Option[T]) XorElse(optb Option[T]) Option[T] {
 	return None[T]()
 }
 
+// XorElse returns [`Some`] if exactly one of `self` and the option computed from `f` is [`Some`],
+// otherwise returns [`None`].
+func (o Option[T]) XorElse(f func() Option[T]) Option[T] {
+	return o.Xor(f())
+}
+
 // Insert inserts `value` into the option, then returns a reference to it.
 func (o *Option[T]) Insert(some T) T {
 	o.value = &some
@@ -254,14 +306,32 @@ func (o *Option[T]) GetOrInsertWith(f func() T) T {
 // Replace replaces the actual value in the option by the value given in parameter,
 // returning the old value if present,
 // leaving a [`Some`] in its place without deinitializing either one.
-func (o *Option[T]) Replace(some T) *Option[T] {
+func (o *Option[T]) Replace(some T) Option[T] {
+	var old = o.Take()
 	o.value = &some
-	return o
+	return old
+}
+
+// Take takes the value out of the option, leaving a [`None`] in its place.
+func (o *Option[T]) Take() Option[T] {
+	var old = *o
+	*o = None[T]()
+	return old
+}
+
+// TakeIf takes the value out of the option, leaving a [`None`] in its place,
+// if the option has value and `predicate` returns `true` for it.
+// Otherwise, leaves the option unchanged and returns [`None`].
+func (o *Option[T]) TakeIf(predicate func(*T) bool) Option[T] {
+	if o.IsSome() && predicate(o.value) {
+		return o.Take()
+	}
+	return None[T]()
 }
 
 // Contains returns `true` if the option is a [`Some`] value containing the given value.
 func Contains[T comparable](o Option[T], x T) bool {
-	return *o.value == x
+	return o.IsSome() && *o.value == x
 }
 
 // ZipWith zips `value` and another `Option` with function `f`.
@@ -274,3 +344,1393 @@ func ZipWith[T any, U any, R any](some Option[T], other Option[U], f func(T, U)
 	}
 	return None[R]()
 }
+
+// AssignField sets the field named `fieldName` of the struct pointed to by `structPtr`
+// to the contained value (if any), and does nothing if the option is [`None`].
+// Returns an error if the field is unknown, unassignable or of a mismatched type.
+func (o Option[T]) AssignField(structPtr any, fieldName string) error {
+	if o.IsNone() {
+		return nil
+	}
+	var v = reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("call Option[%T].AssignField() on non-struct pointer %T", *o.value, structPtr)
+	}
+	var sf, ok = v.Elem().Type().FieldByName(fieldName)
+	if !ok {
+		return fmt.Errorf("unknown field %q in %T", fieldName, structPtr)
+	}
+	var field, err = v.Elem().FieldByIndexErr(sf.Index)
+	if err != nil {
+		return err
+	}
+	if !field.CanSet() {
+		return fmt.Errorf("unassignable field %q in %T", fieldName, structPtr)
+	}
+	var value = reflect.ValueOf(o.value).Elem()
+	if !value.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("cannot assign %s to field %q of type %s", value.Type(), fieldName, field.Type())
+	}
+	field.Set(value)
+	return nil
+}
+
+// SyncMapGet loads the value stored in the map for a key,
+// returns [`None`] if it is absent or not of type `V`.
+func SyncMapGet[K comparable, V any](m *sync.Map, key K) Option[V] {
+	if v, ok := m.Load(key); ok {
+		if value, ok := v.(V); ok {
+			return Some[V](value)
+		}
+	}
+	return None[V]()
+}
+
+// CollectErrors gathers the [`Some`] errors into a slice,
+// returns [`None`] if all of `results` are [`None`].
+func CollectErrors(results ...Option[error]) Option[[]error] {
+	var errs []error
+	for _, r := range results {
+		if r.IsSome() {
+			errs = append(errs, *r.value)
+		}
+	}
+	if len(errs) == 0 {
+		return None[[]error]()
+	}
+	return Some(errs)
+}
+
+// ReplaceIfGreater replaces the contained value by `some` if the option is [`None`]
+// or `some` is greater than the contained value according to `less`,
+// returns whether it was replaced.
+// NOTE: A nil `some` is ignored and `false` is returned, without calling `less`.
+func (o *Option[T]) ReplaceIfGreater(some *T, less func(*T, *T) bool) bool {
+	if some == nil {
+		return false
+	}
+	if o.IsNone() || less(o.value, some) {
+		o.value = some
+		return true
+	}
+	return false
+}
+
+// MapN maps `opts` to `Option[R]` by applying `f` to all of the contained values.
+//
+// If every option of `opts` is [`Some`], this method returns `Some(f(values))`.
+// Otherwise, `None` is returned.
+func MapN[T any, R any](f func([]*T) *R, opts ...Option[T]) Option[R] {
+	var values = make([]*T, 0, len(opts))
+	for _, opt := range opts {
+		if opt.IsNone() {
+			return None[R]()
+		}
+		values = append(values, opt.value)
+	}
+	return Wrap(f(values))
+}
+
+// FillFirstNone inserts `value` into the first [`None`] option of `opts`,
+// returns `false` if all of them are [`Some`].
+func FillFirstNone[T any](opts []*Option[T], value *T) bool {
+	for _, opt := range opts {
+		if opt.IsNone() {
+			opt.Insert(*value)
+			return true
+		}
+	}
+	return false
+}
+
+// OkOrf returns the contained value and nil error (if any),
+// or returns nil and an error formatted according to `format` and `args` (if none).
+// The error is only built if the option is [`None`].
+func OkOrf[T any](o Option[T], format string, args ...any) (*T, error) {
+	if o.IsSome() {
+		return o.value, nil
+	}
+	return nil, fmt.Errorf(format, args...)
+}
+
+// Normalize returns [`None`] if the option is a [`Some`] value containing the zero value of `T`,
+// otherwise returns the option unchanged.
+func Normalize[T comparable](o Option[T]) Option[T] {
+	var zero T
+	if o.IsSome() && *o.value == zero {
+		return None[T]()
+	}
+	return o
+}
+
+// SliceContains returns `true` if any option of `opts` is a [`Some`] value containing `v`.
+func SliceContains[T comparable](opts []Option[T], v T) bool {
+	for _, opt := range opts {
+		if opt.IsSome() && *opt.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SliceContainsNone returns `true` if any option of `opts` is [`None`].
+func SliceContainsNone[T any](opts []Option[T]) bool {
+	for _, opt := range opts {
+		if opt.IsNone() {
+			return true
+		}
+	}
+	return false
+}
+
+// ToMap returns a single-entry map `{key: value}` (if any), or an empty map (if none).
+func (o Option[T]) ToMap(key string) map[string]*T {
+	if o.IsSome() {
+		return map[string]*T{key: o.value}
+	}
+	return map[string]*T{}
+}
+
+// Cast converts an `Option[T]` to `Option[U]` by applying a fallible conversion to a contained value.
+//
+// If `o` is `Some(t)` and `conv(t)` returns `(u, true)`, this method returns `Some(u)`.
+// Otherwise, `None` is returned.
+func Cast[T any, U any](o Option[T], conv func(*T) (*U, bool)) Option[U] {
+	if o.IsSome() {
+		if u, ok := conv(o.value); ok {
+			return Wrap(u)
+		}
+	}
+	return None[U]()
+}
+
+// AndThenInspect returns [`None`] if the option is [`None`], otherwise calls `f` with the
+// wrapped value and returns the result, calling `onInnerNone` if `f` returns [`None`].
+func (o Option[T]) AndThenInspect(f func(*T) Option[T], onInnerNone func()) Option[T] {
+	if o.IsNone() {
+		return o
+	}
+	var r = f(o.value)
+	if r.IsNone() {
+		onInnerNone()
+	}
+	return r
+}
+
+// ScanLines returns an iterator over the lines of `r`,
+// yielding [`None`] for blank lines and [`Some`] for the others.
+// The iteration terminates at EOF or at the first error, which is silently dropped,
+// including a line longer than [`bufio.MaxScanTokenSize`]. Use [`ScanLinesErr`] to get the error.
+func ScanLines(r io.Reader) iter.Seq[Option[string]] {
+	return func(yield func(Option[string]) bool) {
+		for line, err := range ScanLinesErr(r) {
+			if err != nil || !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// ScanLinesErr is like [`ScanLines`], but on error it yields [`None`] and the error as the last item.
+func ScanLinesErr(r io.Reader) iter.Seq2[Option[string], error] {
+	return func(yield func(Option[string], error) bool) {
+		var sc = bufio.NewScanner(r)
+		for sc.Scan() {
+			var line = sc.Text()
+			var opt = None[string]()
+			if strings.TrimSpace(line) != "" {
+				opt = Some(line)
+			}
+			if !yield(opt, nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield(None[string](), err)
+		}
+	}
+}
+
+// Reflect returns the [`reflect.Value`] of the contained value and `true` (if any),
+// or the zero [`reflect.Value`] and `false` (if none).
+func (o Option[T]) Reflect() (reflect.Value, bool) {
+	if o.IsSome() {
+		return reflect.ValueOf(*o.value), true
+	}
+	return reflect.Value{}, false
+}
+
+// First returns the first element of `s`, or [`None`] if `s` is empty.
+func First[T any](s []T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(s[0])
+}
+
+// Last returns the last element of `s`, or [`None`] if `s` is empty.
+func Last[T any](s []T) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(s[len(s)-1])
+}
+
+// At returns the element of `s` at index `i`, or [`None`] if `i` is out of range.
+func At[T any](s []T, i int) Option[T] {
+	if i < 0 || i >= len(s) {
+		return None[T]()
+	}
+	return Some(s[i])
+}
+
+// IntoContext returns a copy of `ctx` in which the contained value is associated with `key` (if any),
+// or returns `ctx` unchanged (if none).
+func (o Option[T]) IntoContext(ctx context.Context, key any) context.Context {
+	if o.IsSome() {
+		return context.WithValue(ctx, key, *o.value)
+	}
+	return ctx
+}
+
+// FromContext returns the value of type `T` associated with `key` in `ctx`,
+// or [`None`] if it is absent or not of type `T`.
+func FromContext[T any](ctx context.Context, key any) Option[T] {
+	if v, ok := ctx.Value(key).(T); ok {
+		return Some(v)
+	}
+	return None[T]()
+}
+
+// DrainFunc calls `produce` repeatedly and collects the contained values
+// until it returns [`None`].
+func DrainFunc[T any](produce func() Option[T]) []*T {
+	var values []*T
+	for opt := produce(); opt.IsSome(); opt = produce() {
+		values = append(values, opt.value)
+	}
+	return values
+}
+
+// Key returns a stable string key of the option, "none" or "some:<value>",
+// usable for grouping options in a map.
+// NOTE: The value is formatted by `%v`, so distinct values with the same
+// representation (e.g. a custom `String` method) produce the same key.
+func (o Option[T]) Key() string {
+	if o.IsNone() {
+		return "none"
+	}
+	return fmt.Sprintf("some:%v", *o.value)
+}
+
+// ResolveAll runs the `loaders` concurrently (at most [`runtime.NumCPU`] at a time)
+// and returns their results in the same order.
+// Loaders that have not started when `ctx` is done are skipped and resolved to [`None`].
+func ResolveAll[T any](ctx context.Context, loaders []func(context.Context) Option[T]) []Option[T] {
+	var results = make([]Option[T], len(loaders))
+	var sem = make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i, loader := range loaders {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			if ctx.Err() != nil {
+				// Both cases may be ready, do not start a loader after cancellation.
+				<-sem
+				continue
+			}
+			wg.Add(1)
+			go func(i int, loader func(context.Context) Option[T]) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				results[i] = loader(ctx)
+			}(i, loader)
+		}
+	}
+	wg.Wait()
+	return results
+}
+
+// ToNullString converts an `Option[string]` to [`sql.NullString`].
+func ToNullString(o Option[string]) sql.NullString {
+	return sql.NullString{String: o.UnwrapOr(""), Valid: o.IsSome()}
+}
+
+// ToNullInt64 converts an `Option[int64]` to [`sql.NullInt64`].
+func ToNullInt64(o Option[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: o.UnwrapOr(0), Valid: o.IsSome()}
+}
+
+// ToNullTime converts an `Option[time.Time]` to [`sql.NullTime`].
+func ToNullTime(o Option[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: o.UnwrapOr(time.Time{}), Valid: o.IsSome()}
+}
+
+// Lift2 lifts a binary function `f` to a function over two options,
+// which returns `Some(f(a, b))` if both options are [`Some`], otherwise [`None`].
+func Lift2[A any, B any, R any](f func(A, B) R) func(Option[A], Option[B]) Option[R] {
+	return func(a Option[A], b Option[B]) Option[R] {
+		if a.IsSome() && b.IsSome() {
+			return Some(f(*a.value, *b.value))
+		}
+		return None[R]()
+	}
+}
+
+// number is a constraint that permits any integer or floating-point type.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// DivOption returns `Some(a / b)` if both options are [`Some`] and the divisor is non-zero,
+// otherwise returns [`None`].
+func DivOption[T number](a, b Option[T]) Option[T] {
+	if a.IsSome() && b.IsSome() && *b.value != 0 {
+		return Some(*a.value / *b.value)
+	}
+	return None[T]()
+}
+
+// PtrAddr returns the address of the contained value, or 0 (if none).
+// NOTE: It is only for diagnostics, such as checking whether two options share the same value.
+func (o Option[T]) PtrAddr() uintptr {
+	return uintptr(unsafe.Pointer(o.value))
+}
+
+// OrJoin returns the contained value and nil error (if any),
+// or returns nil and the joined `errs` (if none).
+// Any nil error values in `errs` are discarded, as in [`errors.Join`],
+// and [`ErrNone`] is returned if there is no non-nil error.
+func (o Option[T]) OrJoin(errs ...error) (*T, error) {
+	if o.IsSome() {
+		return o.value, nil
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return nil, ErrNone
+}
+
+// EncodeSlice writes `opts` to `w` as a JSON array element by element,
+// where [`None`] is encoded as `null` and [`Some`] as the contained value.
+func EncodeSlice[T any](w io.Writer, opts []Option[T]) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, opt := range opts {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		var b, err = json.Marshal(opt.value)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(b); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// MapOrMatch calls `some` with the contained value (if any), or calls `none` (if none),
+// and returns the result.
+func (o Option[T]) MapOrMatch(some func(*T) any, none func() any) any {
+	if o.IsSome() {
+		return some(o.value)
+	}
+	return none()
+}
+
+// RetryWithBackoff calls `f` up to `attempts` times until it returns [`Some`],
+// sleeping `base`, `2*base`, `4*base`... between attempts.
+// Returns [`None`] if all attempts fail or `ctx` is done.
+func RetryWithBackoff[T any](ctx context.Context, attempts int, base time.Duration, f func(context.Context) Option[T]) Option[T] {
+	return retryWithBackoff(ctx, attempts, base, f, sleepContext)
+}
+
+// retryWithBackoff is the implementation of [`RetryWithBackoff`] with an injectable `sleep`.
+func retryWithBackoff[T any](ctx context.Context, attempts int, base time.Duration, f func(context.Context) Option[T], sleep func(context.Context, time.Duration) error) Option[T] {
+	var delay = base
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			if sleep(ctx, delay) != nil {
+				break
+			}
+			if delay <= math.MaxInt64/2 {
+				delay *= 2
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if r := f(ctx); r.IsSome() {
+			return r
+		}
+	}
+	return None[T]()
+}
+
+// sleepContext pauses for the duration `d` or until `ctx` is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	var timer = time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// AsJSONPtr returns the pointer of the contained value, or nil (if none),
+// which can be embedded in a struct field tagged with `json:",omitempty"`.
+func (o Option[T]) AsJSONPtr() *T {
+	return o.value
+}
+
+// ZipOr2Slices merges two option slices element-wise with `a[i].Or(b[i])`.
+// The result has the length of the longer slice, and the missing elements of
+// the shorter slice are treated as [`None`].
+func ZipOr2Slices[T any](a, b []Option[T]) []Option[T] {
+	var n = len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	var r = make([]Option[T], n)
+	for i := range r {
+		if i < len(a) {
+			r[i] = a[i]
+		}
+		if i < len(b) {
+			r[i] = r[i].Or(b[i])
+		}
+	}
+	return r
+}
+
+// NegCache is an [`Option`] slot that also caches a miss,
+// so that a [`None`] result is not computed again.
+// The zero value is an empty slot.
+type NegCache[T any] struct {
+	opt  Option[T]
+	miss bool
+}
+
+// Option returns the cached option.
+func (c NegCache[T]) Option() Option[T] {
+	return c.opt
+}
+
+// GetOrInsertWith inserts a value computed from `f` into the cache if it is empty,
+// then returns the contained value.
+// If `f` reports that its result should be cached and the result is nil,
+// the option stays [`None`] but the miss is remembered, so that `f` is not called again.
+// If `f` reports that its result should not be cached, the result is returned as is.
+func (c *NegCache[T]) GetOrInsertWith(f func() (*T, bool)) *T {
+	if c.opt.IsSome() || c.miss {
+		return c.opt.value
+	}
+	var value, shouldCache = f()
+	if !shouldCache {
+		return value
+	}
+	c.opt, c.miss = Wrap(value), value == nil
+	return value
+}
+
+// CmpOrdered compares two options of an ordered type,
+// where [`None`] is less than any [`Some`], and two [`Some`] values are compared by [`cmp.Compare`].
+func CmpOrdered[T cmp.Ordered](a, b Option[T]) int {
+	switch {
+	case a.IsNone() && b.IsNone():
+		return 0
+	case a.IsNone():
+		return -1
+	case b.IsNone():
+		return 1
+	default:
+		return cmp.Compare(*a.value, *b.value)
+	}
+}
+
+// TakeWhileSome returns the contained values of the leading [`Some`] options of `opts`,
+// and the rest of `opts` starting at the first [`None`].
+func TakeWhileSome[T any](opts []Option[T]) ([]*T, []Option[T]) {
+	var values = make([]*T, 0, len(opts))
+	for i, opt := range opts {
+		if opt.IsNone() {
+			return values, opts[i:]
+		}
+		values = append(values, opt.value)
+	}
+	return values, opts[len(opts):]
+}
+
+// FromResult2 returns `Some(v)` if `err` is nil, otherwise returns [`None`] and discards `err`.
+func FromResult2[T any](v T, err error) Option[T] {
+	if err != nil {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// MapIndexed maps each [`Some`] option of `opts` by applying `f` to its index and contained value,
+// and keeps each [`None`] in its position.
+func MapIndexed[T any, U any](opts []Option[T], f func(i int, v *T) *U) []Option[U] {
+	var r = make([]Option[U], len(opts))
+	for i, opt := range opts {
+		if opt.IsSome() {
+			r[i] = Wrap(f(i, opt.value))
+		}
+	}
+	return r
+}
+
+// SetIfNone sets the option to `some` and returns `true` if it is [`None`] and `some` is not nil,
+// otherwise leaves it unchanged and returns `false`.
+func (o *Option[T]) SetIfNone(some *T) bool {
+	if o.IsSome() || some == nil {
+		return false
+	}
+	o.value = some
+	return true
+}
+
+// TryParseDuration parses a duration string by [`time.ParseDuration`],
+// returns [`None`] if it is invalid.
+func TryParseDuration(s string) Option[time.Duration] {
+	return FromResult2(time.ParseDuration(s))
+}
+
+// AsSlice returns a one-element slice of the contained value (if any), or an empty slice (if none).
+func (o Option[T]) AsSlice() []*T {
+	if o.IsSome() {
+		return []*T{o.value}
+	}
+	return []*T{}
+}
+
+// Spread is the same as [`Option.AsSlice`],
+// named for spreading into a variadic call as `f(o.Spread()...)`.
+func (o Option[T]) Spread() []*T {
+	return o.AsSlice()
+}
+
+// CombineMonoid combines the contained values of `a` and `b` by `combine`,
+// treating [`None`] as the identity element returned by `identity`.
+func CombineMonoid[T any](a Option[T], b Option[T], combine func(*T, *T) *T, identity func() *T) *T {
+	switch {
+	case a.IsSome() && b.IsSome():
+		return combine(a.value, b.value)
+	case a.IsSome():
+		return a.value
+	case b.IsSome():
+		return b.value
+	default:
+		return identity()
+	}
+}
+
+// FromAtomicValue loads the value stored in `v`,
+// returns [`None`] if it is empty or not of type `T`.
+func FromAtomicValue[T any](v *atomic.Value) Option[T] {
+	if value, ok := v.Load().(T); ok {
+		return Some(value)
+	}
+	return None[T]()
+}
+
+// Presence returns the contained value and `true` (if any),
+// or the zero value of `T` and `false` (if none).
+func (o Option[T]) Presence() (value T, present bool) {
+	if o.IsSome() {
+		return *o.value, true
+	}
+	return value, false
+}
+
+// ZipConst returns `Some(f(value, c))` if the option is [`Some`], otherwise returns [`None`].
+func ZipConst[T any, C any](o Option[T], c C, f func(*T, C) *T) Option[T] {
+	if o.IsSome() {
+		return Wrap(f(o.value, c))
+	}
+	return None[T]()
+}
+
+// Recover returns the option if it contains a value,
+// otherwise returns `Wrap(f())` ([`None`] if `f` returns nil).
+func (o Option[T]) Recover(f func() *T) Option[T] {
+	if o.IsSome() {
+		return o
+	}
+	return Wrap(f())
+}
+
+// FlattenInner returns the contained slice of options (if any), or an empty slice (if none).
+func FlattenInner[T any](o Option[[]Option[T]]) []Option[T] {
+	if o.IsSome() {
+		return *o.value
+	}
+	return []Option[T]{}
+}
+
+// Broadcast calls each of `sinks` in order with the contained value (if it has value).
+func (o Option[T]) Broadcast(sinks ...func(*T)) Option[T] {
+	if o.IsSome() {
+		for _, sink := range sinks {
+			sink(o.value)
+		}
+	}
+	return o
+}
+
+// AssertOption returns `Some(v.(T))` if `v` is of type `T`, otherwise returns [`None`].
+func AssertOption[T any](v any) Option[T] {
+	if t, ok := v.(T); ok {
+		return Some(t)
+	}
+	return None[T]()
+}
+
+// JSONLen returns the length of the JSON encoding of the option,
+// which is 4 for `null` (if none), or the length of the marshaled value (if any).
+// NOTE: It marshals the contained value once to measure it.
+func (o Option[T]) JSONLen() (int, error) {
+	if o.IsNone() {
+		return len("null"), nil
+	}
+	var b, err = json.Marshal(o.value)
+	return len(b), err
+}
+
+// Timestamped is an [`Option`] with the time when it was set.
+type Timestamped[T any] struct {
+	Value Option[T]
+	Time  time.Time
+}
+
+// Merge returns the one of `t` and `other` with the later time,
+// where a [`Some`] always takes precedence over a [`None`].
+func (t Timestamped[T]) Merge(other Timestamped[T]) Timestamped[T] {
+	if t.Value.IsSome() != other.Value.IsSome() {
+		if t.Value.IsSome() {
+			return t
+		}
+		return other
+	}
+	if other.Time.After(t.Time) {
+		return other
+	}
+	return t
+}
+
+// UnwrapAllOr returns the contained values of `opts`, replacing each [`None`] with `dflt`.
+func UnwrapAllOr[T any](opts []Option[T], dflt *T) []*T {
+	var values = make([]*T, len(opts))
+	for i, opt := range opts {
+		if opt.IsSome() {
+			values[i] = opt.value
+		} else {
+			values[i] = dflt
+		}
+	}
+	return values
+}
+
+// AndThenIf returns [`None`] if the option is [`None`], otherwise calls `f` with the
+// wrapped value and returns the result if `cond` is `true`, or returns the option unchanged if not.
+func (o Option[T]) AndThenIf(cond bool, f func(*T) Option[T]) Option[T] {
+	if o.IsNone() || !cond {
+		return o
+	}
+	return f(o.value)
+}
+
+// MarshalJSON implements [`json.Marshaler`] interface,
+// encoding [`None`] as `null` and [`Some`] as the contained value.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if o.IsNone() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.value)
+}
+
+// UnmarshalJSON implements [`json.Unmarshaler`] interface,
+// decoding `null` as [`None`] and any other value as [`Some`].
+// NOTE: `Some(None)` of a nested option is encoded as `null`, so it decodes as [`None`].
+func (o *Option[T]) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		*o = None[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
+// DistinctSome returns the distinct contained values of `opts` in first-seen order.
+func DistinctSome[T comparable](opts []Option[T]) []T {
+	var values []T
+	var seen = make(map[T]struct{})
+	for _, opt := range opts {
+		if opt.IsNone() {
+			continue
+		}
+		if _, ok := seen[*opt.value]; !ok {
+			seen[*opt.value] = struct{}{}
+			values = append(values, *opt.value)
+		}
+	}
+	return values
+}
+
+// Scan implements [`sql.Scanner`] interface,
+// scanning SQL `NULL` as [`None`] and any other value as [`Some`].
+func (o *Option[T]) Scan(src any) error {
+	if src == nil {
+		*o = None[T]()
+		return nil
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return fmt.Errorf("call Option[%T].Scan(): %w", n.V, err)
+	}
+	*o = Some(n.V)
+	return nil
+}
+
+// Value implements [`driver.Valuer`] interface,
+// returning nil for [`None`] and the contained value for [`Some`].
+func (o Option[T]) Value() (driver.Value, error) {
+	if o.IsNone() {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(*o.value)
+}
+
+// MarshalText implements [`encoding.TextMarshaler`] interface,
+// encoding [`None`] as empty text and [`Some`] as the text of the contained value,
+// which should implement [`encoding.TextMarshaler`] or be a string, bool, number or [`time.Duration`].
+// NOTE: A [`Some`] of a value encoded as empty text (e.g. `Some("")`) decodes as [`None`].
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if o.IsNone() {
+		return []byte{}, nil
+	}
+	switch v := any(o.value).(type) {
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case *time.Duration:
+		return []byte(v.String()), nil
+	}
+	if b, ok := formatText(reflect.ValueOf(*o.value)); ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("call Option[%T].MarshalText() on unsupported type", *o.value)
+}
+
+// UnmarshalText implements [`encoding.TextUnmarshaler`] interface,
+// decoding empty text as [`None`] and any other text as [`Some`],
+// where `T` should implement [`encoding.TextUnmarshaler`] or be a string, bool, number or [`time.Duration`].
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = None[T]()
+		return nil
+	}
+	var value T
+	switch v := any(&value).(type) {
+	case encoding.TextUnmarshaler:
+		if err := v.UnmarshalText(text); err != nil {
+			return err
+		}
+	case *time.Duration:
+		var d, err = time.ParseDuration(string(text))
+		if err != nil {
+			return err
+		}
+		*v = d
+	default:
+		if ok, err := parseText(reflect.ValueOf(v).Elem(), string(text)); !ok {
+			return fmt.Errorf("call Option[%T].UnmarshalText() on unsupported type", value)
+		} else if err != nil {
+			return err
+		}
+	}
+	*o = Some(value)
+	return nil
+}
+
+// WriteTo writes `format(value)` to `w` (if any), or writes nothing (if none).
+// NOTE: It does not implement [`io.WriterTo`] interface.
+func (o Option[T]) WriteTo(w io.Writer, format func(*T) []byte) (int, error) {
+	if o.IsNone() {
+		return 0, nil
+	}
+	return w.Write(format(o.value))
+}
+
+// Compose returns a function that calls `f` and then calls `g` with the contained value,
+// returning [`None`] without calling `g` if `f` returns [`None`].
+func Compose[T any, U any, V any](f func(*T) Option[U], g func(*U) Option[V]) func(*T) Option[V] {
+	return func(t *T) Option[V] {
+		var u = f(t)
+		if u.IsNone() {
+			return None[V]()
+		}
+		return g(u.value)
+	}
+}
+
+// Guard returns a function that returns `Some(v)` if `pred(v)` is `true`, otherwise [`None`].
+func Guard[T any](pred func(*T) bool) func(*T) Option[T] {
+	return func(v *T) Option[T] {
+		if pred(v) {
+			return Wrap(v)
+		}
+		return None[T]()
+	}
+}
+
+// parseText parses `s` into the addressable `dst` of a string, bool or number kind,
+// returns `false` if the kind is not supported.
+func parseText(dst reflect.Value, s string) (bool, error) {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Bool:
+		var b, err = strconv.ParseBool(s)
+		if err != nil {
+			return true, err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i, err = strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f, err = strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+		dst.SetFloat(f)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// formatText formats `v` of a string, bool or number kind,
+// returns `false` if the kind is not supported.
+func formatText(v reflect.Value) ([]byte, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), true
+	case reflect.Bool:
+		return strconv.AppendBool(nil, v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(nil, v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, v.Float(), 'g', -1, v.Type().Bits()), true
+	}
+	return nil, false
+}
+
+// GobEncode implements [`gob.GobEncoder`] interface,
+// encoding a presence byte followed by the contained value (if any).
+func (o Option[T]) GobEncode() ([]byte, error) {
+	return gobEncode(o.value)
+}
+
+// GobDecode implements [`gob.GobDecoder`] interface,
+// restoring the option encoded by [`Option.GobEncode`].
+func (o *Option[T]) GobDecode(b []byte) error {
+	var value, err = gobDecode[T](b)
+	if err != nil {
+		var t T
+		return fmt.Errorf("call Option[%T].GobDecode(): %w", t, err)
+	}
+	*o = Wrap(value)
+	return nil
+}
+
+// gobEncode encodes a presence byte followed by `*value` (if `value` is not nil).
+func gobEncode[T any](value *T) ([]byte, error) {
+	if value == nil {
+		return []byte{0}, nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(1)
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gobDecode decodes the data encoded by gobEncode.
+func gobDecode[T any](b []byte) (*T, error) {
+	if len(b) == 0 {
+		return nil, errors.New("missing presence byte")
+	}
+	switch b[0] {
+	case 0:
+		return nil, nil
+	case 1:
+		var value = new(T)
+		if err := gob.NewDecoder(bytes.NewReader(b[1:])).Decode(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("invalid presence byte %d", b[0])
+	}
+}
+
+// ValuesSome returns a map of the contained values of `m`, dropping the keys of [`None`].
+func ValuesSome[K comparable, V any](m map[K]Option[V]) map[K]V {
+	var r = make(map[K]V, len(m))
+	for k, opt := range m {
+		if opt.IsSome() {
+			r[k] = *opt.value
+		}
+	}
+	return r
+}
+
+// Tree returns an indented multi-line representation of the nested options,
+// e.g. "Some\n  Some(5)" for `Some(Some(5))`.
+// It is the same as [`Option.String`] for a non-option payload.
+func (o Option[T]) Tree() string {
+	return o.tree("")
+}
+
+func (o Option[T]) tree(indent string) string {
+	if o.IsSome() {
+		if inner, ok := any(*o.value).(interface{ tree(string) string }); ok {
+			return indent + "Some\n" + inner.tree(indent+"  ")
+		}
+	}
+	return indent + o.String()
+}
+
+// Await returns the contained value (if any), or calls `load` and returns its result (if none).
+// Returns `ctx.Err()` if `ctx` is done before `load` completes.
+func (o Option[T]) Await(ctx context.Context, load func(context.Context) (*T, error)) (*T, error) {
+	if o.IsSome() {
+		return o.value, nil
+	}
+	type result struct {
+		value *T
+		err   error
+	}
+	var ch = make(chan result, 1)
+	go func() {
+		var value, err = load(ctx)
+		ch <- result{value: value, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		return r.value, r.err
+	}
+}
+
+// EnumerateSome returns the index and contained value of each [`Some`] option of `opts`.
+func EnumerateSome[T any](opts []Option[T]) []struct {
+	Index int
+	Value *T
+} {
+	var r []struct {
+		Index int
+		Value *T
+	}
+	for i, opt := range opts {
+		if opt.IsSome() {
+			r = append(r, struct {
+				Index int
+				Value *T
+			}{Index: i, Value: opt.value})
+		}
+	}
+	return r
+}
+
+// NextToken advances `sc` to the next token and returns its text,
+// or returns [`None`] at EOF or on error, which can be checked by `sc.Err()`.
+func NextToken(sc *bufio.Scanner) Option[string] {
+	if sc.Scan() {
+		return Some(sc.Text())
+	}
+	return None[string]()
+}
+
+// Flatten converts from `Option[Option[T]]` to `Option[T]`.
+func Flatten[T any](o Option[Option[T]]) Option[T] {
+	if o.IsSome() {
+		return *o.value
+	}
+	return None[T]()
+}
+
+// PresenceBitmap returns a packed bitmap in which bit `i` (least significant bit first)
+// is set if `opts[i]` is [`Some`], like the Arrow validity buffer.
+func PresenceBitmap[T any](opts []Option[T]) []byte {
+	var bitmap = make([]byte, (len(opts)+7)/8)
+	for i, opt := range opts {
+		if opt.IsSome() {
+			bitmap[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bitmap
+}
+
+// ApplyBitmap is the inverse of [`PresenceBitmap`], which returns `Wrap(values[i])`
+// if bit `i` of `bitmap` is set, otherwise [`None`].
+func ApplyBitmap[T any](values []*T, bitmap []byte) []Option[T] {
+	var opts = make([]Option[T], len(values))
+	for i, v := range values {
+		if i/8 < len(bitmap) && bitmap[i/8]&(1<<(i%8)) != 0 {
+			opts[i] = Wrap(v)
+		}
+	}
+	return opts
+}
+
+// Pair is a pair of values.
+type Pair[T any, U any] struct {
+	A T
+	B U
+}
+
+// Zip zips `a` with another `Option`.
+//
+// If `a` is `Some(s)` and `b` is `Some(o)`, this method returns `Some(Pair{s, o})`.
+// Otherwise, `None` is returned.
+func Zip[T any, U any](a Option[T], b Option[U]) Option[Pair[T, U]] {
+	if a.IsSome() && b.IsSome() {
+		return Some(Pair[T, U]{A: *a.value, B: *b.value})
+	}
+	return None[Pair[T, U]]()
+}
+
+// Unzip unzips an option containing a [`Pair`] of two values.
+//
+// If `o` is `Some(Pair{a, b})`, this method returns `(Some(a), Some(b))`.
+// Otherwise, `(None, None)` is returned.
+func Unzip[T any, U any](o Option[Pair[T, U]]) (Option[T], Option[U]) {
+	if o.IsSome() {
+		return Some(o.value.A), Some(o.value.B)
+	}
+	return None[T](), None[U]()
+}
+
+// TryChain is an option pipeline that records the first error,
+// after which all of the following steps are skipped.
+type TryChain[T any] struct {
+	opt Option[T]
+	err error
+}
+
+// Try starts a [`TryChain`] from the option.
+func Try[T any](o Option[T]) TryChain[T] {
+	return TryChain[T]{opt: o}
+}
+
+// Map maps the option by [`Option.Map`] if no error has been recorded.
+func (c TryChain[T]) Map(f func(T) T) TryChain[T] {
+	if c.err == nil {
+		c.opt = c.opt.Map(f)
+	}
+	return c
+}
+
+// AndThen chains the option by [`Option.AndThen`] if no error has been recorded.
+func (c TryChain[T]) AndThen(f func(T) Option[T]) TryChain[T] {
+	if c.err == nil {
+		c.opt = c.opt.AndThen(f)
+	}
+	return c
+}
+
+// TryMap maps the option by a fallible `f` if no error has been recorded,
+// and records the error returned by `f`.
+func (c TryChain[T]) TryMap(f func(T) (T, error)) TryChain[T] {
+	if c.err == nil && c.opt.IsSome() {
+		var value, err = f(*c.opt.value)
+		if err != nil {
+			c.opt, c.err = None[T](), err
+		} else {
+			c.opt = Some(value)
+		}
+	}
+	return c
+}
+
+// TryAndThen chains the option by a fallible `f` if no error has been recorded,
+// and records the error returned by `f`.
+func (c TryChain[T]) TryAndThen(f func(T) (Option[T], error)) TryChain[T] {
+	if c.err == nil && c.opt.IsSome() {
+		var opt, err = f(*c.opt.value)
+		if err != nil {
+			c.opt, c.err = None[T](), err
+		} else {
+			c.opt = opt
+		}
+	}
+	return c
+}
+
+// Result returns the option and the recorded error.
+// The option is [`None`] if an error has been recorded.
+func (c TryChain[T]) Result() (Option[T], error) {
+	return c.opt, c.err
+}
+
+// OkOr returns the contained value and nil error (if any),
+// or returns nil and `err` (if none).
+func (o Option[T]) OkOr(err error) (*T, error) {
+	if o.IsSome() {
+		return o.value, nil
+	}
+	return nil, err
+}
+
+// OkOrElse returns the contained value and nil error (if any),
+// or returns nil and the error computed from `f` (if none).
+func (o Option[T]) OkOrElse(f func() error) (*T, error) {
+	if o.IsSome() {
+		return o.value, nil
+	}
+	return nil, f()
+}
+
+// Get returns the contained value and `true` (if any), or nil and `false` (if none).
+func (o Option[T]) Get() (*T, bool) {
+	return o.value, o.IsSome()
+}
+
+// MapTracked maps the option by applying `f` to a contained value,
+// and reports whether `f` was applied.
+func (o Option[T]) MapTracked(f func(*T) *T) (Option[T], bool) {
+	if o.IsSome() {
+		return Wrap(f(o.value)), true
+	}
+	return None[T](), false
+}
+
+// FlattenTriple converts from `Option[Option[Option[T]]]` to `Option[T]`.
+func FlattenTriple[T any](o Option[Option[Option[T]]]) Option[T] {
+	return Flatten(Flatten(o))
+}
+
+// SampleWith returns the option unchanged with probability `keepProb` according to `rng`,
+// otherwise returns [`None`].
+func (o Option[T]) SampleWith(rng *rand.Rand, keepProb float64) Option[T] {
+	if o.IsSome() && rng.Float64() < keepProb {
+		return o
+	}
+	return None[T]()
+}
+
+// Throttle passes through a [`Some`] at most once per interval.
+// It is not safe for concurrent use.
+type Throttle[T any] struct {
+	interval time.Duration
+	now      func() time.Time
+	last     time.Time
+	passed   bool
+}
+
+// NewThrottle creates a [`Throttle`] with the interval and the clock `now`,
+// which is [`time.Now`] if nil.
+func NewThrottle[T any](interval time.Duration, now func() time.Time) *Throttle[T] {
+	if now == nil {
+		now = time.Now
+	}
+	return &Throttle[T]{interval: interval, now: now}
+}
+
+// Offer returns the option if it is [`Some`] and the interval has elapsed since the last one passed,
+// otherwise returns [`None`].
+func (t *Throttle[T]) Offer(o Option[T]) Option[T] {
+	if o.IsNone() {
+		return o
+	}
+	var now = t.now()
+	if t.passed && now.Sub(t.last) < t.interval {
+		return None[T]()
+	}
+	t.last, t.passed = now, true
+	return o
+}
+
+// Iter returns an iterator yielding the contained value (if any), or nothing (if none).
+func (o Option[T]) Iter() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		if o.IsSome() {
+			yield(o.value)
+		}
+	}
+}
+
+// Go submits a task calling `f` with the contained value to `eg` (if any),
+// or does nothing (if none).
+// NOTE: `eg` is usually an `*errgroup.Group` of `golang.org/x/sync/errgroup`.
+func (o Option[T]) Go(eg interface{ Go(func() error) }, f func(*T) error) {
+	if o.IsSome() {
+		eg.Go(func() error {
+			return f(o.value)
+		})
+	}
+}
+
+// SliceEqual returns `true` if `a` and `b` have the same length,
+// and each pair of options in the same position are [`Equal`].
+func SliceEqual[T comparable](a, b []Option[T]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CollectOptions returns [`Some`] of the contained values of `opts` in order if all of them are [`Some`],
+// otherwise returns [`None`] at the first [`None`].
+func CollectOptions[T any](opts []Option[T]) Option[[]*T] {
+	var values = make([]*T, 0, len(opts))
+	for _, opt := range opts {
+		if opt.IsNone() {
+			return None[[]*T]()
+		}
+		values = append(values, opt.value)
+	}
+	return Some(values)
+}
+
+// Values returns the contained values of the [`Some`] options of `opts` in order,
+// which is an empty (non-nil) slice if all of them are [`None`].
+func Values[T any](opts []Option[T]) []*T {
+	var values = make([]*T, 0, len(opts))
+	for _, opt := range opts {
+		if opt.IsSome() {
+			values = append(values, opt.value)
+		}
+	}
+	return values
+}
+
+// Narrow refines an `Option[T]` to `Option[U]` by applying `narrow` to a contained value.
+//
+// If `o` is `Some(t)` and `narrow(t)` returns `(u, true)`, this method returns `Some(u)`.
+// Otherwise, `None` is returned. It is the same as [`Cast`].
+func Narrow[T any, U any](o Option[T], narrow func(*T) (*U, bool)) Option[U] {
+	return Cast(o, narrow)
+}
+
+// FirstSome returns the first [`Some`] option of `opts`, or [`None`] if all of them are [`None`].
+func FirstSome[T any](opts ...Option[T]) Option[T] {
+	for _, opt := range opts {
+		if opt.IsSome() {
+			return opt
+		}
+	}
+	return None[T]()
+}
+
+// SendCase returns a [`reflect.SelectCase`] sending the contained value to `ch` (if any),
+// or receiving from a nil channel, which never proceeds (if none).
+func (o Option[T]) SendCase(ch chan<- *T) reflect.SelectCase {
+	if o.IsSome() {
+		return reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch), Send: reflect.ValueOf(o.value)}
+	}
+	return reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf((chan *T)(nil))}
+}
+
+// Equal returns `true` if `a` and `b` are both [`None`],
+// or both [`Some`] of equal values (not necessarily the same pointer).
+func Equal[T comparable](a, b Option[T]) bool {
+	if a.IsSome() && b.IsSome() {
+		return *a.value == *b.value
+	}
+	return a.IsNone() && b.IsNone()
+}
+
+// PresenceCounter counts how often options are present.
+// The zero value is ready to use, and it is not safe for concurrent use.
+type PresenceCounter struct {
+	total   int
+	present int
+}
+
+// Observe records an observation, which is usually `o.IsSome()`.
+func (c *PresenceCounter) Observe(present bool) {
+	c.total++
+	if present {
+		c.present++
+	}
+}
+
+// Ratio returns the fraction of present observations,
+// or [`None`] if there is no observation.
+func (c *PresenceCounter) Ratio() Option[float64] {
+	if c.total == 0 {
+		return None[float64]()
+	}
+	return Some(float64(c.present) / float64(c.total))
+}
+
+// ErrNone is returned when a value is required from a [`None`].
+var ErrNone = errors.New("option is none")
+
+// UnwrapInto copies the contained value into `dst` if it passes `validate`,
+// otherwise returns the error of `validate` without writing `dst`.
+// Returns [`ErrNone`] if the option is [`None`].
+func (o Option[T]) UnwrapInto(dst *T, validate func(*T) error) error {
+	if o.IsNone() {
+		return ErrNone
+	}
+	if err := validate(o.value); err != nil {
+		return err
+	}
+	*dst = *o.value
+	return nil
+}
+
+// MergeMaps returns a new map in which the option of each key in either `primary` or `secondary`
+// is `primary[k].Or(secondary[k])`.
+func MergeMaps[K comparable, V any](primary, secondary map[K]Option[V]) map[K]Option[V] {
+	var r = make(map[K]Option[V], len(primary)+len(secondary))
+	for k, opt := range secondary {
+		r[k] = opt
+	}
+	for k, opt := range primary {
+		r[k] = opt.Or(r[k])
+	}
+	return r
+}
@@ -1,8 +1,24 @@
 package option
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
 )
 
 func ExampleOption() {
@@ -34,3 +50,1631 @@ func ExampleOption() {
 	// {2}
 	// Some({1})
 }
+
+func ExampleOption_AssignField() {
+	type A struct {
+		X int
+		Y string
+	}
+	var a = A{X: 1, Y: "a"}
+
+	var err = Some(2).AssignField(&a, "X")
+	fmt.Println(err, a)
+
+	err = None[int]().AssignField(&a, "X")
+	fmt.Println(err, a)
+
+	err = Some("b").AssignField(&a, "X")
+	fmt.Println(err, a)
+
+	type Inner struct {
+		Z int
+	}
+	type B struct {
+		*Inner
+	}
+	var b B
+	err = Some(3).AssignField(&b, "Z")
+	fmt.Println(err, b.Inner)
+
+	// Output:
+	// <nil> {2 a}
+	// <nil> {2 a}
+	// cannot assign string to field "X" of type int {2 a}
+	// reflect: indirection through nil pointer to embedded struct field Inner <nil>
+}
+
+func ExampleSyncMapGet() {
+	var m sync.Map
+	m.Store("a", 1)
+	m.Store("b", "2")
+	fmt.Println(SyncMapGet[string, int](&m, "a"))
+	fmt.Println(SyncMapGet[string, int](&m, "b"))
+	fmt.Println(SyncMapGet[string, int](&m, "c"))
+
+	// Output:
+	// Some(1)
+	// None
+	// None
+}
+
+func ExampleCollectErrors() {
+	fmt.Println(CollectErrors(None[error](), None[error]()))
+	fmt.Println(CollectErrors(None[error](), Some(errors.New("a")), Some(errors.New("b"))))
+
+	// Output:
+	// None
+	// Some([a b])
+}
+
+func ExampleOption_ReplaceIfGreater() {
+	var less = func(a, b *int) bool { return *a < *b }
+	var a = None[int]()
+	var x, y, z = 2, 3, 1
+	var replaced = a.ReplaceIfGreater(nil, less)
+	fmt.Println(replaced, a)
+	replaced = a.ReplaceIfGreater(&x, less)
+	fmt.Println(replaced, a)
+	replaced = a.ReplaceIfGreater(&y, less)
+	fmt.Println(replaced, a)
+	replaced = a.ReplaceIfGreater(&z, less)
+	fmt.Println(replaced, a)
+	replaced = a.ReplaceIfGreater(nil, less)
+	fmt.Println(replaced, a)
+
+	// Output:
+	// false None
+	// true Some(2)
+	// true Some(3)
+	// false Some(3)
+	// false Some(3)
+}
+
+func ExampleMapN() {
+	var sum = func(values []*int) *int {
+		var r int
+		for _, v := range values {
+			r += *v
+		}
+		return &r
+	}
+	fmt.Println(MapN(sum, Some(1), Some(2), Some(3)))
+	fmt.Println(MapN(sum, Some(1), None[int](), Some(3)))
+
+	// Output:
+	// Some(6)
+	// None
+}
+
+func ExampleFillFirstNone() {
+	var a, b, c = Some(1), None[int](), None[int]()
+	var x, y = 2, 3
+	fmt.Println(FillFirstNone([]*Option[int]{&a, &b, &c}, &x))
+	fmt.Println(a, b, c)
+	fmt.Println(FillFirstNone([]*Option[int]{&a, &b}, &y))
+	fmt.Println(a, b, c)
+
+	// Output:
+	// true
+	// Some(1) Some(2) None
+	// false
+	// Some(1) Some(2) None
+}
+
+type countingStringer struct {
+	count *int
+}
+
+func (c countingStringer) String() string {
+	*c.count++
+	return "key"
+}
+
+func ExampleOkOrf() {
+	var count int
+	var s = countingStringer{count: &count}
+
+	var v, err = OkOrf(Some(1), "missing %s", s)
+	fmt.Println(*v, err, count)
+
+	v, err = OkOrf(None[int](), "missing %s", s)
+	fmt.Println(v, err, count)
+
+	// Output:
+	// 1 <nil> 0
+	// <nil> missing key 1
+}
+
+func ExampleNormalize() {
+	fmt.Println(Normalize(Some(0)))
+	fmt.Println(Normalize(Some(5)))
+	fmt.Println(Normalize(None[int]()))
+
+	// Output:
+	// None
+	// Some(5)
+	// None
+}
+
+func ExampleSliceContains() {
+	var opts = []Option[int]{Some(1), None[int](), Some(3)}
+	fmt.Println(SliceContains(opts, 3), SliceContains(opts, 2))
+	fmt.Println(SliceContainsNone(opts), SliceContainsNone(opts[:1]))
+
+	// Output:
+	// true false
+	// true false
+}
+
+func ExampleOption_ToMap() {
+	var m = Some(1).ToMap("a")
+	fmt.Println(len(m), *m["a"])
+	fmt.Println(len(None[int]().ToMap("a")))
+
+	// Output:
+	// 1 1
+	// 0
+}
+
+func ExampleCast() {
+	var atoi = func(s *string) (*int, bool) {
+		i, err := strconv.Atoi(*s)
+		return &i, err == nil
+	}
+	fmt.Println(Cast(Some("1"), atoi))
+	fmt.Println(Cast(Some("x"), atoi))
+	fmt.Println(Cast(None[string](), atoi))
+
+	// Output:
+	// Some(1)
+	// None
+	// None
+}
+
+func ExampleOption_AndThenInspect() {
+	var onInnerNone = func() { fmt.Println("inner none") }
+	var positive = func(x *int) Option[int] {
+		if *x > 0 {
+			return Some(*x)
+		}
+		return None[int]()
+	}
+	fmt.Println(Some(1).AndThenInspect(positive, onInnerNone))
+	fmt.Println(Some(-1).AndThenInspect(positive, onInnerNone))
+	fmt.Println(None[int]().AndThenInspect(positive, onInnerNone))
+
+	// Output:
+	// Some(1)
+	// inner none
+	// None
+	// None
+}
+
+func ExampleScanLines() {
+	for line := range ScanLines(strings.NewReader("a\n\nb\n  \nc")) {
+		fmt.Println(line)
+	}
+
+	// Output:
+	// Some(a)
+	// None
+	// Some(b)
+	// None
+	// Some(c)
+}
+
+func ExampleScanLinesErr() {
+	for line, err := range ScanLinesErr(strings.NewReader("a\n\nb")) {
+		fmt.Println(line, err)
+	}
+
+	var tooLong = strings.Repeat("x", 70*1024) + "\nb"
+	var n int
+	for range ScanLines(strings.NewReader(tooLong)) {
+		n++
+	}
+	fmt.Println(n)
+	for line, err := range ScanLinesErr(strings.NewReader(tooLong)) {
+		fmt.Println(line, err)
+	}
+
+	// Output:
+	// Some(a) <nil>
+	// None <nil>
+	// Some(b) <nil>
+	// 0
+	// None bufio.Scanner: token too long
+}
+
+func ExampleOption_Reflect() {
+	type A struct {
+		X int
+	}
+	var v, ok = Some(A{X: 1}).Reflect()
+	fmt.Println(v.Kind(), v.Interface(), ok)
+
+	v, ok = None[A]().Reflect()
+	fmt.Println(v.IsValid(), ok)
+
+	// Output:
+	// struct {1} true
+	// false false
+}
+
+func ExampleFirst() {
+	fmt.Println(First([]int{1, 2, 3}), Last([]int{1, 2, 3}))
+	fmt.Println(First([]int{}), Last[int](nil))
+
+	// Output:
+	// Some(1) Some(3)
+	// None None
+}
+
+func ExampleAt() {
+	var s = []int{1, 2, 3}
+	fmt.Println(At(s, 1), At(s, 3), At(s, -1))
+
+	// Output:
+	// Some(2) None None
+}
+
+func ExampleOption_IntoContext() {
+	type key struct{}
+	var ctx = Some(1).IntoContext(context.Background(), key{})
+	fmt.Println(FromContext[int](ctx, key{}))
+
+	ctx = None[int]().IntoContext(context.Background(), key{})
+	fmt.Println(FromContext[int](ctx, key{}))
+
+	// Output:
+	// Some(1)
+	// None
+}
+
+func ExampleDrainFunc() {
+	var n int
+	var values = DrainFunc(func() Option[int] {
+		n++
+		if n > 3 {
+			return None[int]()
+		}
+		return Some(n)
+	})
+	for _, v := range values {
+		fmt.Println(*v)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleOption_Key() {
+	fmt.Println(None[string]().Key(), Some("a").Key(), Some("b").Key())
+
+	// Output:
+	// none some:a some:b
+}
+
+func ExampleResolveAll() {
+	var loaders []func(context.Context) Option[int]
+	for i := 0; i < 5; i++ {
+		var i = i
+		loaders = append(loaders, func(context.Context) Option[int] {
+			if i%2 == 0 {
+				return Some(i)
+			}
+			return None[int]()
+		})
+	}
+	fmt.Println(ResolveAll(context.Background(), loaders))
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	fmt.Println(ResolveAll(ctx, loaders))
+
+	// Output:
+	// [Some(0) None Some(2) None Some(4)]
+	// [None None None None None]
+}
+
+func ExampleToNullString() {
+	fmt.Println(ToNullString(Some("a")), ToNullString(None[string]()))
+	fmt.Println(ToNullInt64(Some[int64](1)), ToNullInt64(None[int64]()))
+	var t = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	fmt.Println(ToNullTime(Some(t)).Time.Equal(t), ToNullTime(Some(t)).Valid)
+	fmt.Println(ToNullTime(None[time.Time]()).Time.IsZero(), ToNullTime(None[time.Time]()).Valid)
+
+	// Output:
+	// {a true} { false}
+	// {1 true} {0 false}
+	// true true
+	// true false
+}
+
+func ExampleLift2() {
+	var add = Lift2(func(a int, b int) int { return a + b })
+	fmt.Println(add(Some(1), Some(2)))
+	fmt.Println(add(Some(1), None[int]()))
+	fmt.Println(add(None[int](), Some(2)))
+	fmt.Println(add(None[int](), None[int]()))
+
+	// Output:
+	// Some(3)
+	// None
+	// None
+	// None
+}
+
+func ExampleDivOption() {
+	fmt.Println(DivOption(Some(6), Some(3)))
+	fmt.Println(DivOption(Some(6), Some(0)))
+	fmt.Println(DivOption(Some(6.0), None[float64]()))
+	fmt.Println(DivOption(None[float64](), Some(2.0)))
+
+	// Output:
+	// Some(2)
+	// None
+	// None
+	// None
+}
+
+func ExampleOption_PtrAddr() {
+	var x = 1
+	var a, b = Wrap(&x), Wrap(&x)
+	fmt.Println(a.PtrAddr() == b.PtrAddr(), a.PtrAddr() == Some(x).PtrAddr())
+	fmt.Println(None[int]().PtrAddr())
+
+	// Output:
+	// true false
+	// 0
+}
+
+func ExampleOption_OrJoin() {
+	var errA, errB = errors.New("a"), errors.New("b")
+	var v, err = Some(1).OrJoin(errA, errB)
+	fmt.Println(*v, err)
+
+	v, err = None[int]().OrJoin(errA, nil, errB)
+	fmt.Println(v, errors.Is(err, errA), errors.Is(err, errB))
+	fmt.Println(err)
+
+	v, err = None[int]().OrJoin(nil)
+	fmt.Println(v, err == ErrNone)
+
+	// Output:
+	// 1 <nil>
+	// <nil> true true
+	// a
+	// b
+	// <nil> true
+}
+
+func ExampleEncodeSlice() {
+	var x, y = "a", "b"
+	var buf bytes.Buffer
+	var err = EncodeSlice(&buf, []Option[string]{Wrap(&x), None[string](), Wrap(&y)})
+	fmt.Println(buf.String(), err)
+
+	var b, _ = json.Marshal([]*string{&x, nil, &y})
+	fmt.Println(string(b))
+
+	// Output:
+	// ["a",null,"b"] <nil>
+	// ["a",null,"b"]
+}
+
+func ExampleOption_MapOrMatch() {
+	var some = func(x *int) any { return *x * 2 }
+	var none = func() any { return "none" }
+	fmt.Println(Some(1).MapOrMatch(some, none))
+	fmt.Println(None[int]().MapOrMatch(some, none))
+
+	// Output:
+	// 2
+	// none
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	var delays []time.Duration
+	var sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	var calls int
+	var r = retryWithBackoff(context.Background(), 4, time.Second, func(context.Context) Option[int] {
+		calls++
+		return None[int]()
+	}, sleep)
+	if r.IsSome() || calls != 4 || fmt.Sprint(delays) != "[1s 2s 4s]" {
+		t.Fatalf("got %v, %d calls, delays %v", r, calls, delays)
+	}
+
+	calls, delays = 0, nil
+	r = retryWithBackoff(context.Background(), 4, time.Second, func(context.Context) Option[int] {
+		calls++
+		if calls == 2 {
+			return Some(calls)
+		}
+		return None[int]()
+	}, sleep)
+	if r.Unwrap() != 2 || calls != 2 || fmt.Sprint(delays) != "[1s]" {
+		t.Fatalf("got %v, %d calls, delays %v", r, calls, delays)
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	r = retryWithBackoff(ctx, 4, time.Second, func(context.Context) Option[int] {
+		calls++
+		return Some(1)
+	}, sleep)
+	if r.IsSome() || calls != 0 {
+		t.Fatalf("got %v, %d calls", r, calls)
+	}
+
+	delays = nil
+	retryWithBackoff(context.Background(), 66, time.Duration(math.MaxInt64/4), func(context.Context) Option[int] {
+		return None[int]()
+	}, sleep)
+	for _, d := range delays {
+		if d <= 0 {
+			t.Fatalf("delay overflowed: %v", delays)
+		}
+	}
+}
+
+func ExampleOption_AsJSONPtr() {
+	type A struct {
+		X *int `json:"x,omitempty"`
+	}
+	fmt.Println(None[int]().AsJSONPtr() == nil)
+
+	var b, _ = json.Marshal(A{X: Some(1).AsJSONPtr()})
+	fmt.Println(string(b))
+	b, _ = json.Marshal(A{X: None[int]().AsJSONPtr()})
+	fmt.Println(string(b))
+
+	// Output:
+	// true
+	// {"x":1}
+	// {}
+}
+
+func ExampleZipOr2Slices() {
+	var a = []Option[int]{Some(1), None[int](), None[int]()}
+	var b = []Option[int]{Some(10), Some(20), None[int](), Some(40)}
+	fmt.Println(ZipOr2Slices(a, b))
+	fmt.Println(ZipOr2Slices(b[:1], a))
+
+	// Output:
+	// [Some(1) Some(20) None Some(40)]
+	// [Some(10) None None]
+}
+
+func ExampleNegCache() {
+	var calls int
+	var miss = func() (*int, bool) {
+		calls++
+		return nil, true
+	}
+	var a NegCache[int]
+	var v = a.GetOrInsertWith(miss)
+	fmt.Println(v, a.Option(), calls)
+	v = a.GetOrInsertWith(miss)
+	fmt.Println(v, a.Option(), calls, a.Option() == None[int]())
+
+	var hit = func() (*int, bool) {
+		calls++
+		var x = 1
+		return &x, true
+	}
+	var b NegCache[int]
+	v = b.GetOrInsertWith(hit)
+	fmt.Println(*v, b.Option(), calls)
+	v = b.GetOrInsertWith(hit)
+	fmt.Println(*v, b.Option(), calls)
+
+	// Output:
+	// <nil> None 1
+	// <nil> None 1 true
+	// 1 Some(1) 2
+	// 1 Some(1) 2
+}
+
+func ExampleCmpOrdered() {
+	fmt.Println(CmpOrdered(None[int](), Some(1)), CmpOrdered(Some(1), None[int]()), CmpOrdered(None[int](), None[int]()))
+	fmt.Println(CmpOrdered(Some(1), Some(2)), CmpOrdered(Some(2), Some(1)), CmpOrdered(Some(1), Some(1)))
+
+	// Output:
+	// -1 1 0
+	// -1 1 0
+}
+
+func ExampleTakeWhileSome() {
+	var values, rest = TakeWhileSome([]Option[int]{None[int](), Some(1)})
+	fmt.Println(len(values), rest)
+
+	values, rest = TakeWhileSome([]Option[int]{Some(1), Some(2), None[int](), Some(3)})
+	fmt.Println(*values[0], *values[1], rest)
+
+	values, rest = TakeWhileSome([]Option[int]{Some(1), Some(2)})
+	fmt.Println(len(values), len(rest))
+
+	// Output:
+	// 0 [None Some(1)]
+	// 1 2 [None Some(3)]
+	// 2 0
+}
+
+func ExampleFromResult2() {
+	fmt.Println(FromResult2(strconv.Atoi("1")))
+	fmt.Println(FromResult2(strconv.Atoi("x")))
+
+	// Output:
+	// Some(1)
+	// None
+}
+
+func ExampleMapIndexed() {
+	var r = MapIndexed([]Option[string]{Some("a"), None[string](), Some("c")}, func(i int, v *string) *string {
+		var s = strconv.Itoa(i) + *v
+		return &s
+	})
+	fmt.Println(r)
+
+	// Output:
+	// [Some(0a) None Some(2c)]
+}
+
+func ExampleOption_SetIfNone() {
+	var x, y = 1, 2
+	var a = None[int]()
+	var ok = a.SetIfNone(nil)
+	fmt.Println(ok, a)
+	ok = a.SetIfNone(&x)
+	fmt.Println(ok, a)
+	ok = a.SetIfNone(&y)
+	fmt.Println(ok, a)
+
+	// Output:
+	// false None
+	// true Some(1)
+	// false Some(1)
+}
+
+func ExampleTryParseDuration() {
+	fmt.Println(TryParseDuration("5s"))
+	fmt.Println(TryParseDuration("5x"))
+
+	// Output:
+	// Some(5s)
+	// None
+}
+
+func ExampleOption_Spread() {
+	var count = func(values ...*int) int { return len(values) }
+	fmt.Println(count(Some(1).Spread()...), count(None[int]().Spread()...))
+
+	// Output:
+	// 1 0
+}
+
+func ExampleCombineMonoid() {
+	var add = func(a, b *int) *int {
+		var r = *a + *b
+		return &r
+	}
+	var zero = func() *int { return new(int) }
+	fmt.Println(*CombineMonoid(Some(1), Some(2), add, zero))
+	fmt.Println(*CombineMonoid(None[int](), Some(2), add, zero))
+	fmt.Println(*CombineMonoid(None[int](), None[int](), add, zero))
+
+	// Output:
+	// 3
+	// 2
+	// 0
+}
+
+func ExampleFromAtomicValue() {
+	var a, b atomic.Value
+	a.Store(1)
+	fmt.Println(FromAtomicValue[int](&a))
+	fmt.Println(FromAtomicValue[string](&a))
+	fmt.Println(FromAtomicValue[int](&b))
+
+	// Output:
+	// Some(1)
+	// None
+	// None
+}
+
+func ExampleOption_Presence() {
+	fmt.Println(Some("a").Presence())
+	fmt.Println(None[int]().Presence())
+
+	// Output:
+	// a true
+	// 0 false
+}
+
+func ExampleZipConst() {
+	var mul = func(x *int, c int) *int {
+		var r = *x * c
+		return &r
+	}
+	fmt.Println(ZipConst(Some(2), 3, mul))
+	fmt.Println(ZipConst(None[int](), 3, mul))
+
+	// Output:
+	// Some(6)
+	// None
+}
+
+func ExampleOption_Recover() {
+	var one = func() *int {
+		var x = 1
+		return &x
+	}
+	var null = func() *int { return nil }
+	fmt.Println(Some(2).Recover(one), None[int]().Recover(one), None[int]().Recover(null))
+
+	// Output:
+	// Some(2) Some(1) None
+}
+
+func ExampleFlattenInner() {
+	fmt.Println(FlattenInner(Some([]Option[int]{Some(1), None[int]()})))
+	fmt.Println(FlattenInner(None[[]Option[int]]()))
+
+	// Output:
+	// [Some(1) None]
+	// []
+}
+
+func ExampleOption_Broadcast() {
+	var a = func(x *int) { fmt.Println("a", *x) }
+	var b = func(x *int) { fmt.Println("b", *x) }
+	fmt.Println(Some(1).Broadcast(a, b))
+	fmt.Println(None[int]().Broadcast(a, b))
+
+	// Output:
+	// a 1
+	// b 1
+	// Some(1)
+	// None
+}
+
+func ExampleAssertOption() {
+	fmt.Println(AssertOption[int](1))
+	fmt.Println(AssertOption[int]("1"))
+	fmt.Println(AssertOption[int](nil))
+
+	// Output:
+	// Some(1)
+	// None
+	// None
+}
+
+func ExampleOption_JSONLen() {
+	type A struct {
+		X int
+	}
+	var n, err = Some(A{X: 1}).JSONLen()
+	var b, _ = json.Marshal(A{X: 1})
+	fmt.Println(n, err, len(b))
+
+	n, err = None[A]().JSONLen()
+	b, _ = json.Marshal(nil)
+	fmt.Println(n, err, len(b))
+
+	// Output:
+	// 7 <nil> 7
+	// 4 <nil> 4
+}
+
+func ExampleTimestamped_Merge() {
+	var t1 = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var t2 = t1.Add(time.Hour)
+	var a = Timestamped[int]{Value: Some(1), Time: t1}
+	var b = Timestamped[int]{Value: Some(2), Time: t2}
+	var c = Timestamped[int]{Value: None[int](), Time: t2}
+	fmt.Println(a.Merge(b).Value, b.Merge(a).Value)
+	fmt.Println(a.Merge(c).Value, c.Merge(a).Value)
+
+	// Output:
+	// Some(2) Some(2)
+	// Some(1) Some(1)
+}
+
+func ExampleSome() {
+	type A struct {
+		X int
+	}
+	var x = 1
+	fmt.Println(Some(A{X: 1}), Some(A{}).IsSome())
+	fmt.Println(Some(1), Some(0).IsSome())
+	fmt.Println(Some(&x).Unwrap() == &x, Some[*int](nil).IsSome())
+
+	// Output:
+	// Some({1}) true
+	// Some(1) true
+	// true true
+}
+
+func ExampleUnwrapAllOr() {
+	var dflt = 0
+	for _, v := range UnwrapAllOr([]Option[int]{Some(1), None[int](), Some(3)}, &dflt) {
+		fmt.Println(*v)
+	}
+
+	// Output:
+	// 1
+	// 0
+	// 3
+}
+
+func ExampleOption_Unwrap() {
+	type A struct {
+		X int
+	}
+	var a = Some(A{X: 1})
+	var x = a.Unwrap()
+	x.X = 2
+	var y = a.UnwrapOr(A{})
+	y.X = 3
+	var z = a.UnwrapOrElse(func() A { return A{} })
+	z.X = 4
+	fmt.Println(a, x, y, z)
+
+	// Output:
+	// Some({1}) {2} {3} {4}
+}
+
+func ExampleOption_AndThenIf() {
+	var double = func(x *int) Option[int] { return Some(*x * 2) }
+	fmt.Println(Some(1).AndThenIf(true, double), Some(1).AndThenIf(false, double))
+	fmt.Println(None[int]().AndThenIf(true, double), None[int]().AndThenIf(false, double))
+
+	// Output:
+	// Some(2) Some(1)
+	// None None
+}
+
+func TestOptionJSON(t *testing.T) {
+	type A struct {
+		X Option[int]            `json:"x"`
+		Y Option[[]int]          `json:"y"`
+		Z Option[map[string]int] `json:"z"`
+		N Option[Option[int]]    `json:"n"`
+	}
+	var cases = []struct {
+		input  string
+		want   string
+		hasErr bool
+	}{
+		{input: `{"x":null,"y":null,"z":null,"n":null}`, want: "{None None None None}"},
+		{input: `{}`, want: "{None None None None}"},
+		{input: `{"x":1,"y":[1,2],"z":{"a":1},"n":2}`, want: "{Some(1) Some([1 2]) Some(map[a:1]) Some(Some(2))}"},
+		{input: `{"x":0,"y":[],"z":{}}`, want: "{Some(0) Some([]) Some(map[]) None}"},
+		{input: `{"x":"1"}`, hasErr: true},
+		{input: `{"x":}`, hasErr: true},
+	}
+	for _, c := range cases {
+		var a A
+		var err = json.Unmarshal([]byte(c.input), &a)
+		if c.hasErr {
+			if err == nil {
+				t.Errorf("unmarshal %s: expect error", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unmarshal %s: %v", c.input, err)
+		}
+		if got := fmt.Sprint(a); got != c.want {
+			t.Errorf("unmarshal %s: got %s, want %s", c.input, got, c.want)
+		}
+		var b []byte
+		b, err = json.Marshal(a)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", a, err)
+		}
+		var a2 A
+		if err = json.Unmarshal(b, &a2); err != nil {
+			t.Fatalf("unmarshal %s: %v", b, err)
+		}
+		if got := fmt.Sprint(a2); got != c.want {
+			t.Errorf("round-trip %s: got %s, want %s", c.input, got, c.want)
+		}
+	}
+}
+
+func ExampleDistinctSome() {
+	fmt.Println(DistinctSome([]Option[int]{Some(2), None[int](), Some(1), Some(2), None[int](), Some(3), Some(1)}))
+
+	// Output:
+	// [2 1 3]
+}
+
+func TestOptionScan(t *testing.T) {
+	var now = time.Now()
+	var check = func(name string, err error, got, want any) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+
+	var i Option[int]
+	check("int64", i.Scan(int64(1)), i, Some(1))
+	check("[]byte to int", i.Scan([]byte("2")), i, Some(2))
+	check("nil", i.Scan(nil), i, None[int]())
+
+	var f Option[float64]
+	check("float64", f.Scan(1.5), f, Some(1.5))
+
+	var b Option[bool]
+	check("bool", b.Scan(true), b, Some(true))
+
+	var s Option[string]
+	check("[]byte", s.Scan([]byte("a")), s, Some("a"))
+	check("string", s.Scan("b"), s, Some("b"))
+
+	var raw = []byte("c")
+	var bs Option[[]byte]
+	check("[]byte to []byte", bs.Scan(raw), bs, Some([]byte("c")))
+	raw[0] = 'd'
+	check("[]byte copied", nil, bs, Some([]byte("c")))
+
+	var tm Option[time.Time]
+	check("time.Time", tm.Scan(now), tm, Some(now))
+
+	var ns Option[sql.NullString]
+	check("sql.Scanner", ns.Scan("e"), ns, Some(sql.NullString{String: "e", Valid: true}))
+
+	if err := i.Scan(now); err == nil {
+		t.Errorf("scan time.Time into int: expect error")
+	}
+	if err := i.Scan("x"); err == nil {
+		t.Errorf("scan invalid string into int: expect error")
+	}
+	if err := i.Scan(1.7); err == nil {
+		t.Errorf("scan float into int: expect error")
+	}
+
+	var u8 Option[uint8]
+	if err := u8.Scan(int64(300)); err == nil {
+		t.Errorf("scan 300 into uint8: expect error")
+	}
+	check("uint8 unchanged", nil, u8, None[uint8]())
+	var i32 Option[int32]
+	if err := i32.Scan(int64(math.MaxInt64)); err == nil {
+		t.Errorf("scan MaxInt64 into int32: expect error")
+	}
+
+	check("int64 to bool", b.Scan(int64(1)), b, Some(true))
+	check("int64 to string", s.Scan(int64(7)), s, Some("7"))
+}
+
+func ExampleOption_Value() {
+	fmt.Println(Some(1).Value())
+	fmt.Println(Some("a").Value())
+	fmt.Println(None[int]().Value())
+
+	// Output:
+	// 1 <nil>
+	// a <nil>
+	// <nil> <nil>
+}
+
+func ExampleOption_WriteTo() {
+	var format = func(x *int) []byte { return []byte(strconv.Itoa(*x)) }
+	var buf bytes.Buffer
+	var n, err = Some(12).WriteTo(&buf, format)
+	fmt.Println(n, err, buf.String())
+
+	buf.Reset()
+	n, err = None[int]().WriteTo(&buf, format)
+	fmt.Println(n, err, buf.Len())
+
+	// Output:
+	// 2 <nil> 12
+	// 0 <nil> 0
+}
+
+func ExampleCompose() {
+	var parse = func(s *string) Option[int] {
+		return FromResult2(strconv.Atoi(*s))
+	}
+	var double = func(x *int) Option[int] {
+		fmt.Println("double", *x)
+		return Some(*x * 2)
+	}
+	var f = Compose(parse, double)
+	var a, b = "2", "x"
+	fmt.Println(f(&a))
+	fmt.Println(f(&b))
+
+	// Output:
+	// double 2
+	// Some(4)
+	// None
+}
+
+func ExampleGuard() {
+	var positive = Guard(func(x *int) bool { return *x > 0 })
+	var a, b = 1, -1
+	fmt.Println(positive(&a), positive(&b))
+
+	// Output:
+	// Some(1) None
+}
+
+type level int
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func ExampleOption_MarshalText() {
+	var i Option[int]
+	var err = i.UnmarshalText([]byte("12"))
+	var b, _ = i.MarshalText()
+	fmt.Println(i, err, string(b))
+
+	var d Option[time.Duration]
+	err = d.UnmarshalText([]byte("1m30s"))
+	b, _ = d.MarshalText()
+	fmt.Println(d, err, string(b))
+
+	var l Option[level]
+	err = l.UnmarshalText([]byte("high"))
+	b, _ = l.MarshalText()
+	fmt.Println(l, err, string(b))
+
+	err = l.UnmarshalText(nil)
+	b, _ = l.MarshalText()
+	fmt.Println(l, err, len(b))
+
+	fmt.Println(l.UnmarshalText([]byte("middle")))
+	fmt.Println(Some(struct{}{}).MarshalText())
+
+	var s = Some("")
+	b, _ = s.MarshalText()
+	err = s.UnmarshalText(b)
+	fmt.Println(len(b), s, err)
+
+	// Output:
+	// Some(12) <nil> 12
+	// Some(1m30s) <nil> 1m30s
+	// Some(1) <nil> high
+	// None <nil> 0
+	// unknown level "middle"
+	// [] call Option[struct {}].MarshalText() on unsupported type
+	// 0 None <nil>
+}
+
+func ExampleOption_GobEncode() {
+	var opts = []Option[int]{Some(1), None[int](), Some(0)}
+	var buf bytes.Buffer
+	var err = gob.NewEncoder(&buf).Encode(opts)
+	fmt.Println(err)
+
+	var decoded []Option[int]
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	fmt.Println(decoded, err)
+
+	// Output:
+	// <nil>
+	// [Some(1) None Some(0)] <nil>
+}
+
+func ExampleValuesSome() {
+	fmt.Println(ValuesSome(map[string]Option[int]{"a": Some(1), "b": None[int](), "c": Some(3)}))
+
+	// Output:
+	// map[a:1 c:3]
+}
+
+func ExampleOption_Take() {
+	var a = Some(1)
+	var b = a.Take()
+	fmt.Println(a, b)
+
+	b = a.Take()
+	fmt.Println(a, b)
+
+	// Output:
+	// None Some(1)
+	// None None
+}
+
+func ExampleOption_Tree() {
+	fmt.Println(Some(Some(5)).Tree())
+	fmt.Println(Some(Some(None[int]())).Tree())
+	fmt.Println(Some(5).Tree())
+
+	// Output:
+	// Some
+	//   Some(5)
+	// Some
+	//   Some
+	//     None
+	// Some(5)
+}
+
+func ExampleOption_TakeIf() {
+	var calls int
+	var even = func(x *int) bool {
+		calls++
+		return *x%2 == 0
+	}
+	var a, b, c = Some(2), Some(1), None[int]()
+	var r = a.TakeIf(even)
+	fmt.Println(a, r)
+	r = b.TakeIf(even)
+	fmt.Println(b, r)
+	r = c.TakeIf(even)
+	fmt.Println(c, r, calls)
+
+	// Output:
+	// None Some(2)
+	// Some(1) None
+	// None None 2
+}
+
+func ExampleOption_Await() {
+	var load = func(ctx context.Context) (*int, error) {
+		var x = 2
+		return &x, nil
+	}
+	var v, err = Some(1).Await(context.Background(), load)
+	fmt.Println(*v, err)
+
+	v, err = None[int]().Await(context.Background(), load)
+	fmt.Println(*v, err)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	v, err = None[int]().Await(ctx, func(ctx context.Context) (*int, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	fmt.Println(v, err)
+
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// <nil> context canceled
+}
+
+func ExampleEnumerateSome() {
+	for _, e := range EnumerateSome([]Option[string]{None[string](), Some("a"), None[string](), Some("b")}) {
+		fmt.Println(e.Index, *e.Value)
+	}
+
+	// Output:
+	// 1 a
+	// 3 b
+}
+
+func ExampleOption_Replace() {
+	var a = Some(1)
+	var old = a.Replace(2)
+	fmt.Println(a, old)
+
+	var b = None[int]()
+	old = b.Replace(3)
+	fmt.Println(b, old)
+
+	// Output:
+	// Some(2) Some(1)
+	// Some(3) None
+}
+
+func ExampleNextToken() {
+	var sc = bufio.NewScanner(strings.NewReader("a b  c"))
+	sc.Split(bufio.ScanWords)
+	for tok := NextToken(sc); tok.IsSome(); tok = NextToken(sc) {
+		fmt.Println(tok)
+	}
+	fmt.Println(sc.Err())
+
+	sc = bufio.NewScanner(strings.NewReader(""))
+	fmt.Println(NextToken(sc))
+
+	// Output:
+	// Some(a)
+	// Some(b)
+	// Some(c)
+	// <nil>
+	// None
+}
+
+func ExampleOption_Xor() {
+	var some, none = Some(1), None[int]()
+	fmt.Println(some.Xor(Some(2)), some.Xor(none), none.Xor(Some(2)), none.Xor(none))
+	fmt.Println(
+		some.XorElse(func() Option[int] { return Some(2) }),
+		some.XorElse(None[int]),
+		none.XorElse(func() Option[int] { return Some(2) }),
+		none.XorElse(None[int]),
+	)
+
+	// Output:
+	// None Some(1) Some(2) None
+	// None Some(1) Some(2) None
+}
+
+func ExampleFlatten() {
+	fmt.Println(Flatten(Some(Some(1))), Flatten(Some(None[int]())), Flatten(None[Option[int]]()))
+
+	// Output:
+	// Some(1) None None
+}
+
+func ExamplePresenceBitmap() {
+	var opts = []Option[int]{Some(0), None[int](), Some(2), None[int](), None[int](), None[int](), None[int](), None[int](), Some(8)}
+	var bitmap = PresenceBitmap(opts)
+	fmt.Printf("%08b\n", bitmap)
+
+	var values = make([]*int, len(opts))
+	for i, opt := range opts {
+		values[i] = opt.AsJSONPtr()
+	}
+	fmt.Println(ApplyBitmap(values, bitmap))
+
+	// Output:
+	// [00000101 00000001]
+	// [Some(0) None Some(2) None None None None None Some(8)]
+}
+
+func ExampleZip() {
+	var z = Zip(Some(1), Some("a"))
+	fmt.Println(z)
+	fmt.Println(Unzip(z))
+
+	z = Zip(Some(1), None[string]())
+	fmt.Println(z)
+	fmt.Println(Unzip(z))
+
+	// Output:
+	// Some({1 a})
+	// Some(1) Some(a)
+	// None
+	// None None
+}
+
+func ExampleTryChain() {
+	var calls int
+	var double = func(x int) int {
+		calls++
+		return x * 2
+	}
+	var check = func(x int) (int, error) {
+		if x > 10 {
+			return 0, fmt.Errorf("%d is too large", x)
+		}
+		return x, nil
+	}
+
+	fmt.Println(Try(Some(2)).Map(double).TryMap(check).Map(double).Result())
+	fmt.Println(calls)
+
+	calls = 0
+	fmt.Println(Try(Some(20)).TryMap(check).Map(double).AndThen(func(x int) Option[int] {
+		calls++
+		return Some(x)
+	}).Result())
+	fmt.Println(calls)
+
+	// Output:
+	// Some(8) <nil>
+	// 2
+	// None 20 is too large
+	// 0
+}
+
+func ExampleOption_OkOr() {
+	var errMissing = errors.New("missing")
+	var v, err = Some(1).OkOr(errMissing)
+	fmt.Println(*v, err)
+	v, err = None[int]().OkOr(errMissing)
+	fmt.Println(v, err)
+
+	v, err = Some(1).OkOrElse(func() error { return errMissing })
+	fmt.Println(*v, err)
+	v, err = None[int]().OkOrElse(func() error { return errMissing })
+	fmt.Println(v, err)
+
+	// Output:
+	// 1 <nil>
+	// <nil> missing
+	// 1 <nil>
+	// <nil> missing
+}
+
+func ExampleOption_Get() {
+	var x = 1
+	var a = Wrap(&x)
+	var v, ok = a.Get()
+	fmt.Println(*v, ok, v == &x)
+
+	v, ok = None[int]().Get()
+	fmt.Println(v, ok)
+
+	// Output:
+	// 1 true true
+	// <nil> false
+}
+
+func ExampleOption_MapTracked() {
+	var double = func(x *int) *int {
+		var r = *x * 2
+		return &r
+	}
+	fmt.Println(Some(1).MapTracked(double))
+	fmt.Println(None[int]().MapTracked(double))
+
+	// Output:
+	// Some(2) true
+	// None false
+}
+
+func ExampleFromPtr() {
+	var x = 1
+	fmt.Println(FromPtr(&x), FromPtr[int](nil), Wrap[int](nil))
+	fmt.Println(FromTuple(&x, true), FromTuple(&x, false))
+
+	// Output:
+	// Some(1) None None
+	// Some(1) None
+}
+
+func ExampleFlattenTriple() {
+	fmt.Println(FlattenTriple(Some(Some(Some(1)))))
+	fmt.Println(FlattenTriple(Some(Some(None[int]()))))
+	fmt.Println(FlattenTriple(Some(None[Option[int]]())))
+	fmt.Println(FlattenTriple(None[Option[Option[int]]]()))
+
+	// Output:
+	// Some(1)
+	// None
+	// None
+	// None
+}
+
+func ExampleOption_SampleWith() {
+	var rng = rand.New(rand.NewSource(1))
+	fmt.Println(Some(1).SampleWith(rng, 1), Some(1).SampleWith(rng, 0), None[int]().SampleWith(rng, 1))
+
+	var kept int
+	for i := 0; i < 1000; i++ {
+		if Some(i).SampleWith(rng, 0.5).IsSome() {
+			kept++
+		}
+	}
+	fmt.Println(kept > 400 && kept < 600)
+
+	// Output:
+	// Some(1) None None
+	// true
+}
+
+func ExampleOption_ToOptnil() {
+	var x = 1
+	var a = Wrap(&x)
+	fmt.Println(a.ToOptnil().Unwrap() == &x, None[int]().ToOptnil())
+	fmt.Println(Ptr(&x).ToOption().PtrAddr() == a.PtrAddr(), Nil[int]().ToOption())
+
+	// Output:
+	// true Nil
+	// true None
+}
+
+func ExampleOption_AsSlice() {
+	var x = 1
+	var s = Wrap(&x).AsSlice()
+	fmt.Println(len(s), s[0] == &x, len(None[int]().AsSlice()))
+
+	// Output:
+	// 1 true 0
+}
+
+func ExampleThrottle() {
+	var now = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var t = NewThrottle[int](time.Second, func() time.Time { return now })
+	fmt.Println(t.Offer(Some(1)), t.Offer(Some(2)), t.Offer(None[int]()))
+
+	now = now.Add(500 * time.Millisecond)
+	fmt.Println(t.Offer(Some(3)))
+
+	now = now.Add(500 * time.Millisecond)
+	fmt.Println(t.Offer(Some(4)), t.Offer(Some(5)))
+
+	// Output:
+	// Some(1) None None
+	// None
+	// Some(4) None
+}
+
+func ExampleOption_Iter() {
+	for v := range Some(1).Iter() {
+		fmt.Println(*v)
+		break
+	}
+	for range None[int]().Iter() {
+		fmt.Println("unreachable")
+	}
+	None[int]().Iter()(func(*int) bool {
+		panic("unreachable")
+	})
+
+	// Output:
+	// 1
+}
+
+// serialGroup runs each task immediately and keeps the first error, like a serial `errgroup.Group`.
+type serialGroup struct{ err error }
+
+func (g *serialGroup) Go(f func() error) {
+	if err := f(); err != nil && g.err == nil {
+		g.err = err
+	}
+}
+
+func (g *serialGroup) Wait() error { return g.err }
+
+func ExampleOption_Go() {
+	var eg serialGroup
+	var ran int
+	var f = func(x *int) error {
+		ran++
+		return fmt.Errorf("failed on %d", *x)
+	}
+	None[int]().Go(&eg, f)
+	fmt.Println(eg.Wait(), ran)
+
+	Some(1).Go(&eg, f)
+	fmt.Println(eg.Wait(), ran)
+
+	// Output:
+	// <nil> 0
+	// failed on 1 1
+}
+
+func ExampleSliceEqual() {
+	var a = []Option[int]{Some(1), None[int](), Some(3)}
+	fmt.Println(SliceEqual(a, []Option[int]{Some(1), None[int](), Some(3)}))
+	fmt.Println(SliceEqual(a, a[:2]))
+	fmt.Println(SliceEqual(a, []Option[int]{Some(1), None[int](), Some(4)}))
+	fmt.Println(SliceEqual(a, []Option[int]{Some(1), Some(2), Some(3)}))
+
+	// Output:
+	// true
+	// false
+	// false
+	// false
+}
+
+func ExampleCollectOptions() {
+	var values = CollectOptions([]Option[int]{Some(1), Some(2)}).Unwrap()
+	fmt.Println(*values[0], *values[1])
+	fmt.Println(CollectOptions([]Option[int]{Some(1), None[int](), Some(3)}))
+	fmt.Println(CollectOptions([]Option[int]{}))
+
+	// Output:
+	// 1 2
+	// None
+	// Some([])
+}
+
+func ExampleValues() {
+	var values = Values([]Option[int]{Some(1), None[int](), Some(3)})
+	fmt.Println(len(values), *values[0], *values[1])
+
+	values = Values([]Option[int]{None[int](), None[int]()})
+	fmt.Println(len(values), values != nil)
+
+	// Output:
+	// 2 1 3
+	// 0 true
+}
+
+func ExampleNarrow() {
+	var toString = func(v *any) (*string, bool) {
+		s, ok := (*v).(string)
+		return &s, ok
+	}
+	fmt.Println(Narrow(Some[any]("a"), toString))
+	fmt.Println(Narrow(Some[any](1), toString))
+
+	// Output:
+	// Some(a)
+	// None
+}
+
+func ExampleFirstSome() {
+	var none = None[int]()
+	fmt.Println(FirstSome(Some(1), none, Some(3)))
+	fmt.Println(FirstSome(none, Some(2), Some(3)))
+	fmt.Println(FirstSome(none, none, Some(3)))
+	fmt.Println(FirstSome(none, none), FirstSome[int]())
+
+	// Output:
+	// Some(1)
+	// Some(2)
+	// Some(3)
+	// None None
+}
+
+func ExampleOption_SendCase() {
+	var ch = make(chan *int, 1)
+	var dflt = reflect.SelectCase{Dir: reflect.SelectDefault}
+
+	var chosen, _, _ = reflect.Select([]reflect.SelectCase{None[int]().SendCase(ch), dflt})
+	fmt.Println(chosen, len(ch))
+
+	chosen, _, _ = reflect.Select([]reflect.SelectCase{Some(1).SendCase(ch), dflt})
+	fmt.Println(chosen, *<-ch)
+
+	// Output:
+	// 1 0
+	// 0 1
+}
+
+func ExampleEqual() {
+	var x, y = 1, 1
+	fmt.Println(Equal(Wrap(&x), Wrap(&y)), Equal(Some(1), Some(2)))
+	fmt.Println(Equal(None[int](), None[int]()), Equal(Some(1), None[int]()))
+
+	// Output:
+	// true false
+	// true false
+}
+
+func ExamplePresenceCounter() {
+	var c PresenceCounter
+	fmt.Println(c.Ratio())
+	for _, opt := range []Option[int]{Some(1), None[int](), Some(3), Some(4)} {
+		c.Observe(opt.IsSome())
+	}
+	fmt.Println(c.Ratio())
+
+	// Output:
+	// None
+	// Some(0.75)
+}
+
+func ExampleOption_UnwrapInto() {
+	var positive = func(x *int) error {
+		if *x <= 0 {
+			return fmt.Errorf("%d is not positive", *x)
+		}
+		return nil
+	}
+	var dst int
+	var err = None[int]().UnwrapInto(&dst, positive)
+	fmt.Println(err, errors.Is(err, ErrNone), dst)
+
+	err = Some(1).UnwrapInto(&dst, positive)
+	fmt.Println(err, dst)
+
+	err = Some(-1).UnwrapInto(&dst, positive)
+	fmt.Println(err, dst)
+
+	// Output:
+	// option is none true 0
+	// <nil> 1
+	// -1 is not positive 1
+}
+
+func ExampleContains() {
+	fmt.Println(Contains(Some(1), 1), Contains(Some(1), 2), Contains(None[int](), 0))
+
+	// Output:
+	// true false false
+}
+
+func ExampleOption_UnwrapOrZero() {
+	type A struct {
+		X int
+	}
+	fmt.Println(Some(A{X: 1}).UnwrapOrZero(), None[A]().UnwrapOrZero())
+	fmt.Println(Some([]int{1}).UnwrapOrZero(), None[[]int]().UnwrapOrZero() == nil)
+	fmt.Println(Some(1).UnwrapOrZero(), None[int]().UnwrapOrZero())
+
+	// Output:
+	// {1} {0}
+	// [1] true
+	// 1 0
+}
+
+func ExampleMergeMaps() {
+	var primary = map[string]Option[int]{"a": Some(1), "b": None[int](), "c": Some(3), "e": None[int]()}
+	var secondary = map[string]Option[int]{"b": Some(20), "c": Some(30), "d": Some(40), "e": None[int]()}
+	fmt.Println(MergeMaps(primary, secondary))
+
+	// Output:
+	// map[a:Some(1) b:Some(20) c:Some(3) d:Some(40) e:None]
+}
+
+func TestResolveAllCancel(t *testing.T) {
+	var n = runtime.NumCPU()
+	var started = make(chan struct{}, n+2)
+	var loaders []func(context.Context) Option[int]
+	for i := 0; i < n+2; i++ {
+		var i = i
+		loaders = append(loaders, func(ctx context.Context) Option[int] {
+			started <- struct{}{}
+			<-ctx.Done()
+			return Some(i)
+		})
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	var done = make(chan []Option[int])
+	go func() {
+		done <- ResolveAll(ctx, loaders)
+	}()
+	for i := 0; i < n; i++ {
+		<-started
+	}
+	cancel()
+	var results = <-done
+	for i, r := range results {
+		if i < n && !Equal(r, Some(i)) {
+			t.Errorf("started loader %d: got %v, want Some(%d)", i, r, i)
+		}
+		if i >= n && r.IsSome() {
+			t.Errorf("pending loader %d: got %v, want None", i, r)
+		}
+	}
+}
+
+func TestOptionJSONNested(t *testing.T) {
+	// JSON has a single `null`, so the inner None of `Some(None)` cannot be told apart
+	// from the outer None: it is encoded as `null` and decodes as None.
+	var cases = []struct {
+		value   Option[Option[int]]
+		json    string
+		decoded string
+	}{
+		{value: Some(Some(1)), json: `1`, decoded: "Some(Some(1))"},
+		{value: Some(None[int]()), json: `null`, decoded: "None"},
+		{value: None[Option[int]](), json: `null`, decoded: "None"},
+	}
+	for _, c := range cases {
+		var b, err = json.Marshal(c.value)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", c.value, err)
+		}
+		if string(b) != c.json {
+			t.Errorf("marshal %v: got %s, want %s", c.value, b, c.json)
+		}
+		var decoded Option[Option[int]]
+		if err = json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("unmarshal %s: %v", b, err)
+		}
+		if got := decoded.String(); got != c.decoded {
+			t.Errorf("round-trip %v: got %s, want %s", c.value, got, c.decoded)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package optiontest provides assertions on options for tests.
+package optiontest
+
+import (
+	"testing"
+
+	"github.com/henrylee2cn/option"
+)
+
+// MustBeSome returns the contained value of `o`,
+// or fails the test by `t.Fatalf` if it is [`option.None`].
+func MustBeSome[T any](t testing.TB, o option.Option[T]) *T {
+	t.Helper()
+	var v, ok = o.Get()
+	if !ok {
+		var zero T
+		t.Fatalf("expect Option[%T] to be Some, but got None", zero)
+		return nil
+	}
+	return v
+}
+
+// MustBeNone fails the test by `t.Fatalf` if `o` is [`option.Some`].
+func MustBeNone[T any](t testing.TB, o option.Option[T]) {
+	t.Helper()
+	if v, ok := o.Get(); ok {
+		t.Fatalf("expect Option[%T] to be None, but got %s", *v, o)
+	}
+}
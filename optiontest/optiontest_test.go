@@ -0,0 +1,50 @@
+package optiontest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/henrylee2cn/option"
+)
+
+type fakeTB struct {
+	testing.TB
+	helper bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {
+	f.helper = true
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func ExampleMustBeSome() {
+	var t = new(fakeTB)
+	var v = MustBeSome(t, option.Some(1))
+	fmt.Println(*v, t.helper, t.msg == "")
+
+	t = new(fakeTB)
+	v = MustBeSome(t, option.None[int]())
+	fmt.Println(v, t.helper, t.msg)
+
+	// Output:
+	// 1 true true
+	// <nil> true expect Option[int] to be Some, but got None
+}
+
+func ExampleMustBeNone() {
+	var t = new(fakeTB)
+	MustBeNone(t, option.None[int]())
+	fmt.Println(t.helper, t.msg == "")
+
+	t = new(fakeTB)
+	MustBeNone(t, option.Some(1))
+	fmt.Println(t.helper, t.msg)
+
+	// Output:
+	// true true
+	// true expect Option[int] to be None, but got Some(1)
+}
@@ -1,7 +1,12 @@
 package option
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // Optnil represents an optional value:
@@ -86,6 +91,15 @@ func (o Optnil[T]) UnwrapOrElse(defaultPtr func() *T) *T {
 	return defaultPtr()
 }
 
+// UnwrapOrZero returns a copy of the contained value or the zero value of `T`.
+func (o Optnil[T]) UnwrapOrZero() T {
+	if o.NotNil() {
+		return *o.value
+	}
+	var zero T
+	return zero
+}
+
 // UnwrapUnchecked returns the contained value.
 func (o Optnil[T]) UnwrapUnchecked() *T {
 	return o.value
@@ -210,8 +224,8 @@ func (o Optnil[T]) OrElse(f func() Optnil[T]) Optnil[T] {
 	return o
 }
 
-// XorElse [`NonNil`] if exactly one of `self`, `optb` is [`NonNil`], otherwise returns [`Nil`].
-func (o Optnil[T]) XorElse(optb Optnil[T]) Optnil[T] {
+// Xor returns [`NonNil`] if exactly one of `self`, `optb` is [`NonNil`], otherwise returns [`Nil`].
+func (o Optnil[T]) Xor(optb Optnil[T]) Optnil[T] {
 	if o.NotNil() && optb.IsNil() {
 		return o
 	}
@@ -221,6 +235,12 @@ func (o Optnil[T]) XorElse(optb Optnil[T]) Optnil[T] {
 	return Nil[T]()
 }
 
+// XorElse returns [`NonNil`] if exactly one of `self` and the option computed from `f` is [`NonNil`],
+// otherwise returns [`Nil`].
+func (o Optnil[T]) XorElse(f func() Optnil[T]) Optnil[T] {
+	return o.Xor(f())
+}
+
 // Insert inserts `value` into the option, then returns a reference to it.
 func (o *Optnil[T]) Insert(some *T) *T {
 	o.value = some
@@ -248,14 +268,37 @@ func (o *Optnil[T]) GetOrInsertWith(f func() *T) *T {
 // Replace replaces the actual value in the option by the value given in parameter,
 // returning the old value if present,
 // leaving a [`NonNil`] in its place without deinitializing either one.
-func (o *Optnil[T]) Replace(some *T) *Optnil[T] {
+func (o *Optnil[T]) Replace(some *T) Optnil[T] {
+	var old = o.Take()
 	o.value = some
-	return o
+	return old
 }
 
-// OptnilContains returns `true` if the option is a [`NonNil`] value containing the given value.
+// Take takes the value out of the option, leaving a [`Nil`] in its place.
+func (o *Optnil[T]) Take() Optnil[T] {
+	var old = *o
+	*o = Nil[T]()
+	return old
+}
+
+// TakeIf takes the value out of the option, leaving a [`Nil`] in its place,
+// if the option has value and `predicate` returns `true` for it.
+// Otherwise, leaves the option unchanged and returns [`Nil`].
+func (o *Optnil[T]) TakeIf(predicate func(*T) bool) Optnil[T] {
+	if o.NotNil() && predicate(o.value) {
+		return o.Take()
+	}
+	return Nil[T]()
+}
+
+// OptnilContains returns `true` if the option is a [`NonNil`] pointing to a value equal to `*x`.
 func OptnilContains[T comparable](o Optnil[T], x *T) bool {
-	return o.value == x
+	return o.NotNil() && x != nil && *o.value == *x
+}
+
+// OptnilContainsPtr returns `true` if the option is a [`NonNil`] of the same pointer as `x`.
+func OptnilContainsPtr[T any](o Optnil[T], x *T) bool {
+	return o.NotNil() && o.value == x
 }
 
 // OptnilZipWith zips `value` and another `Optnil` with function `f`.
@@ -268,3 +311,163 @@ func OptnilZipWith[T any, U any, R any](some Optnil[T], other Optnil[U], f func(
 	}
 	return Nil[R]()
 }
+
+// MarshalJSON implements [`json.Marshaler`] interface,
+// encoding [`Nil`] as `null` and [`NonNil`] as the pointed-to value.
+func (o Optnil[T]) MarshalJSON() ([]byte, error) {
+	if o.IsNil() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.value)
+}
+
+// UnmarshalJSON implements [`json.Unmarshaler`] interface,
+// decoding `null` as [`Nil`] and any other value as [`NonNil`] of a newly allocated `*T`.
+// NOTE: If `T` is a pointer type, a [`NonNil`] pointing to a nil `T` is encoded as `null`,
+// so it decodes as [`Nil`].
+func (o *Optnil[T]) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		*o = Nil[T]()
+		return nil
+	}
+	var value = new(T)
+	if err := json.Unmarshal(b, value); err != nil {
+		return err
+	}
+	*o = Ptr(value)
+	return nil
+}
+
+// Scan implements [`sql.Scanner`] interface,
+// scanning SQL `NULL` as [`Nil`] and any other value as [`NonNil`] of a newly allocated `*T`.
+func (o *Optnil[T]) Scan(src any) error {
+	if src == nil {
+		*o = Nil[T]()
+		return nil
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return fmt.Errorf("call Optnil[%T].Scan(): %w", n.V, err)
+	}
+	*o = Ptr(&n.V)
+	return nil
+}
+
+// Value implements [`driver.Valuer`] interface,
+// returning nil for [`Nil`] and the pointed-to value for [`NonNil`].
+func (o Optnil[T]) Value() (driver.Value, error) {
+	if o.IsNil() {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(*o.value)
+}
+
+// GobEncode implements [`gob.GobEncoder`] interface,
+// encoding a presence byte followed by the pointed-to value (if any).
+func (o Optnil[T]) GobEncode() ([]byte, error) {
+	return gobEncode(o.value)
+}
+
+// GobDecode implements [`gob.GobDecoder`] interface,
+// restoring the option encoded by [`Optnil.GobEncode`].
+func (o *Optnil[T]) GobDecode(b []byte) error {
+	var value, err = gobDecode[T](b)
+	if err != nil {
+		var t T
+		return fmt.Errorf("call Optnil[%T].GobDecode(): %w", t, err)
+	}
+	*o = Ptr(value)
+	return nil
+}
+
+// FlattenOptnil converts from `Optnil[Optnil[T]]` to `Optnil[T]`.
+func FlattenOptnil[T any](o Optnil[Optnil[T]]) Optnil[T] {
+	if o.NotNil() {
+		return *o.value
+	}
+	return Nil[T]()
+}
+
+// OkOr returns the contained value and nil error (if any),
+// or returns nil and `err` (if nil).
+func (o Optnil[T]) OkOr(err error) (*T, error) {
+	if o.NotNil() {
+		return o.value, nil
+	}
+	return nil, err
+}
+
+// OkOrElse returns the contained value and nil error (if any),
+// or returns nil and the error computed from `f` (if nil).
+func (o Optnil[T]) OkOrElse(f func() error) (*T, error) {
+	if o.NotNil() {
+		return o.value, nil
+	}
+	return nil, f()
+}
+
+// Get returns the contained value and `true` (if any), or nil and `false` (if nil).
+func (o Optnil[T]) Get() (*T, bool) {
+	return o.value, o.NotNil()
+}
+
+// AsSlice returns a one-element slice of the contained value (if any), or an empty slice (if nil).
+func (o Optnil[T]) AsSlice() []*T {
+	if o.NotNil() {
+		return []*T{o.value}
+	}
+	return []*T{}
+}
+
+// Iter returns an iterator yielding the contained value (if any), or nothing (if nil).
+func (o Optnil[T]) Iter() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		if o.NotNil() {
+			yield(o.value)
+		}
+	}
+}
+
+// CollectOptnils returns [`NonNil`] of the contained values of `opts` in order if all of them are [`NonNil`],
+// otherwise returns [`Nil`] at the first [`Nil`].
+func CollectOptnils[T any](opts []Optnil[T]) Optnil[[]*T] {
+	var values = make([]*T, 0, len(opts))
+	for _, opt := range opts {
+		if opt.IsNil() {
+			return Nil[[]*T]()
+		}
+		values = append(values, opt.value)
+	}
+	return Ptr(&values)
+}
+
+// OptnilValues returns the contained values of the [`NonNil`] options of `opts` in order,
+// which is an empty (non-nil) slice if all of them are [`Nil`].
+func OptnilValues[T any](opts []Optnil[T]) []*T {
+	var values = make([]*T, 0, len(opts))
+	for _, opt := range opts {
+		if opt.NotNil() {
+			values = append(values, opt.value)
+		}
+	}
+	return values
+}
+
+// FirstNonNil returns the first [`NonNil`] option of `opts`, or [`Nil`] if all of them are [`Nil`].
+func FirstNonNil[T any](opts ...Optnil[T]) Optnil[T] {
+	for _, opt := range opts {
+		if opt.NotNil() {
+			return opt
+		}
+	}
+	return Nil[T]()
+}
+
+// EqualOptnil returns `true` if `a` and `b` are both [`Nil`],
+// or both [`NonNil`] pointing to equal values (not necessarily the same pointer).
+func EqualOptnil[T comparable](a, b Optnil[T]) bool {
+	if a.NotNil() && b.NotNil() {
+		return *a.value == *b.value
+	}
+	return a.IsNil() && b.IsNil()
+}
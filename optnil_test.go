@@ -1,8 +1,13 @@
 package option
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"testing"
 )
 
 func ExampleOptnil() {
@@ -34,3 +39,360 @@ func ExampleOptnil() {
 	// &{2}
 	// NonNil(&{1})
 }
+
+func TestOptnilJSON(t *testing.T) {
+	type A struct {
+		X Optnil[int]  `json:"x"`
+		P Optnil[*int] `json:"p"`
+	}
+	var cases = []struct {
+		input  string
+		want   string
+		hasErr bool
+	}{
+		{input: `{"x":null,"p":null}`, want: "Nil Nil"},
+		{input: `{}`, want: "Nil Nil"},
+		{input: `{"x":1,"p":2}`, want: "1 2"},
+		{input: `{"x":"1"}`, hasErr: true},
+		{input: `{"x":}`, hasErr: true},
+	}
+	var format = func(a A) string {
+		var x, p = "Nil", "Nil"
+		if a.X.NotNil() {
+			x = fmt.Sprint(*a.X.Unwrap())
+		}
+		if a.P.NotNil() {
+			p = fmt.Sprint(**a.P.Unwrap())
+		}
+		return x + " " + p
+	}
+	for _, c := range cases {
+		var a A
+		var err = json.Unmarshal([]byte(c.input), &a)
+		if c.hasErr {
+			if err == nil {
+				t.Errorf("unmarshal %s: expect error", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unmarshal %s: %v", c.input, err)
+		}
+		if got := format(a); got != c.want {
+			t.Errorf("unmarshal %s: got %s, want %s", c.input, got, c.want)
+		}
+		var b []byte
+		b, err = json.Marshal(a)
+		if err != nil {
+			t.Fatalf("marshal %s: %v", c.input, err)
+		}
+		var a2 A
+		if err = json.Unmarshal(b, &a2); err != nil {
+			t.Fatalf("unmarshal %s: %v", b, err)
+		}
+		if got := format(a2); got != c.want {
+			t.Errorf("round-trip %s: got %s, want %s", c.input, got, c.want)
+		}
+	}
+}
+
+func TestOptnilScan(t *testing.T) {
+	var rows = []any{int64(1), nil, []byte("2"), nil, int64(3)}
+	var want = []string{"1", "Nil", "2", "Nil", "3"}
+	var o Optnil[int]
+	var prev *int
+	for i, row := range rows {
+		if err := o.Scan(row); err != nil {
+			t.Fatalf("row %d: %v", i, err)
+		}
+		var got = "Nil"
+		if o.NotNil() {
+			got = strconv.Itoa(*o.Unwrap())
+			if o.Unwrap() == prev {
+				t.Errorf("row %d: reused the previous pointer", i)
+			}
+			prev = o.Unwrap()
+		}
+		if got != want[i] {
+			t.Errorf("row %d: got %s, want %s", i, got, want[i])
+		}
+	}
+	if *prev != 3 {
+		t.Errorf("got %d, want 3", *prev)
+	}
+	if err := o.Scan(true); err == nil {
+		t.Errorf("scan bool into int: expect error")
+	}
+
+	var u8 = Ptr(new(uint8))
+	var before = u8.Unwrap()
+	if err := u8.Scan(int64(300)); err == nil {
+		t.Errorf("scan 300 into uint8: expect error")
+	}
+	if u8.Unwrap() != before || *before != 0 {
+		t.Errorf("scan 300 into uint8: option changed")
+	}
+}
+
+func ExampleOptnil_Value() {
+	var x = 1
+	fmt.Println(Ptr(&x).Value())
+	fmt.Println(Nil[int]().Value())
+
+	// Output:
+	// 1 <nil>
+	// <nil> <nil>
+}
+
+func ExampleOptnil_GobEncode() {
+	var x, y = 1, 0
+	var opts = []Optnil[int]{Ptr(&x), Nil[int](), Ptr(&y)}
+	var buf bytes.Buffer
+	var err = gob.NewEncoder(&buf).Encode(opts)
+	fmt.Println(err)
+
+	var decoded []Optnil[int]
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	fmt.Println(err)
+	for _, o := range decoded {
+		fmt.Println(o.ToOption())
+	}
+
+	// Output:
+	// <nil>
+	// <nil>
+	// Some(1)
+	// None
+	// Some(0)
+}
+
+func ExampleOptnil_Take() {
+	var x = 1
+	var a = Ptr(&x)
+	var b = a.Take()
+	fmt.Println(a, b.Unwrap() == &x)
+
+	b = a.Take()
+	fmt.Println(a, b)
+
+	// Output:
+	// Nil true
+	// Nil Nil
+}
+
+func ExampleOptnil_TakeIf() {
+	var calls int
+	var even = func(x *int) bool {
+		calls++
+		return *x%2 == 0
+	}
+	var x, y = 2, 1
+	var a, b, c = Ptr(&x), Ptr(&y), Nil[int]()
+	var r = a.TakeIf(even)
+	fmt.Println(a, r.Unwrap() == &x)
+	r = b.TakeIf(even)
+	fmt.Println(b.Unwrap() == &y, r)
+	r = c.TakeIf(even)
+	fmt.Println(c, r, calls)
+
+	// Output:
+	// Nil true
+	// true Nil
+	// Nil Nil 2
+}
+
+func ExampleOptnil_Replace() {
+	var x, y = 1, 2
+	var a = Ptr(&x)
+	var old = a.Replace(&y)
+	fmt.Println(a.Unwrap() == &y, old.Unwrap() == &x)
+
+	var b = Nil[int]()
+	old = b.Replace(&x)
+	fmt.Println(b.Unwrap() == &x, old)
+
+	// Output:
+	// true true
+	// true Nil
+}
+
+func ExampleOptnil_Xor() {
+	var x, y = 1, 2
+	var nonNil, isNil = Ptr(&x), Nil[int]()
+	fmt.Println(nonNil.Xor(Ptr(&y)), *nonNil.Xor(isNil).Unwrap(), *isNil.Xor(Ptr(&y)).Unwrap(), isNil.Xor(isNil))
+	fmt.Println(
+		nonNil.XorElse(func() Optnil[int] { return Ptr(&y) }),
+		*nonNil.XorElse(Nil[int]).Unwrap(),
+		*isNil.XorElse(func() Optnil[int] { return Ptr(&y) }).Unwrap(),
+		isNil.XorElse(Nil[int]),
+	)
+
+	// Output:
+	// Nil 1 2 Nil
+	// Nil 1 2 Nil
+}
+
+func ExampleFlattenOptnil() {
+	var x = 1
+	var inner, innerNil = Ptr(&x), Nil[int]()
+	fmt.Println(*FlattenOptnil(Ptr(&inner)).Unwrap(), FlattenOptnil(Ptr(&innerNil)), FlattenOptnil(Nil[Optnil[int]]()))
+
+	// Output:
+	// 1 Nil Nil
+}
+
+func ExampleOptnil_OkOr() {
+	var errMissing = errors.New("missing")
+	var x = 1
+	var v, err = Ptr(&x).OkOr(errMissing)
+	fmt.Println(v == &x, err)
+	v, err = Nil[int]().OkOr(errMissing)
+	fmt.Println(v, err)
+
+	v, err = Ptr(&x).OkOrElse(func() error { return errMissing })
+	fmt.Println(v == &x, err)
+	v, err = Nil[int]().OkOrElse(func() error { return errMissing })
+	fmt.Println(v, err)
+
+	// Output:
+	// true <nil>
+	// <nil> missing
+	// true <nil>
+	// <nil> missing
+}
+
+func ExampleOptnil_Get() {
+	var x = 1
+	var v, ok = Ptr(&x).Get()
+	fmt.Println(*v, ok, v == &x)
+
+	v, ok = Nil[int]().Get()
+	fmt.Println(v, ok)
+
+	// Output:
+	// 1 true true
+	// <nil> false
+}
+
+func ExampleOptnil_AsSlice() {
+	var x = 1
+	var s = Ptr(&x).AsSlice()
+	fmt.Println(len(s), s[0] == &x, len(Nil[int]().AsSlice()))
+
+	// Output:
+	// 1 true 0
+}
+
+func ExampleOptnil_Iter() {
+	var x = 1
+	for v := range Ptr(&x).Iter() {
+		fmt.Println(*v, v == &x)
+		break
+	}
+	for range Nil[int]().Iter() {
+		fmt.Println("unreachable")
+	}
+
+	// Output:
+	// 1 true
+}
+
+func ExampleCollectOptnils() {
+	var x, y = 1, 2
+	var values = *CollectOptnils([]Optnil[int]{Ptr(&x), Ptr(&y)}).Unwrap()
+	fmt.Println(*values[0], *values[1])
+	fmt.Println(CollectOptnils([]Optnil[int]{Ptr(&x), Nil[int](), Ptr(&y)}))
+	fmt.Println(CollectOptnils([]Optnil[int]{}))
+
+	// Output:
+	// 1 2
+	// Nil
+	// NonNil(&[])
+}
+
+func ExampleOptnilValues() {
+	var x, y = 1, 3
+	var values = OptnilValues([]Optnil[int]{Ptr(&x), Nil[int](), Ptr(&y)})
+	fmt.Println(len(values), values[0] == &x, values[1] == &y)
+
+	values = OptnilValues([]Optnil[int]{Nil[int]()})
+	fmt.Println(len(values), values != nil)
+
+	// Output:
+	// 2 true true
+	// 0 true
+}
+
+func ExampleFirstNonNil() {
+	var x, y, z = 1, 2, 3
+	var isNil = Nil[int]()
+	fmt.Println(*FirstNonNil(Ptr(&x), isNil, Ptr(&z)).Unwrap())
+	fmt.Println(*FirstNonNil(isNil, Ptr(&y), Ptr(&z)).Unwrap())
+	fmt.Println(*FirstNonNil(isNil, isNil, Ptr(&z)).Unwrap())
+	fmt.Println(FirstNonNil(isNil, isNil), FirstNonNil[int]())
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// Nil Nil
+}
+
+func ExampleEqualOptnil() {
+	var x, y, z = 1, 1, 2
+	fmt.Println(EqualOptnil(Ptr(&x), Ptr(&y)), EqualOptnil(Ptr(&x), Ptr(&z)))
+	fmt.Println(EqualOptnil(Nil[int](), Nil[int]()), EqualOptnil(Ptr(&x), Nil[int]()))
+
+	// Output:
+	// true false
+	// true false
+}
+
+func ExampleOptnilContains() {
+	var x, y = 1, 1
+	fmt.Println(OptnilContains(Ptr(&x), &x), OptnilContains(Ptr(&x), &y), OptnilContains(Nil[int](), &x), OptnilContains(Ptr(&x), nil))
+	fmt.Println(OptnilContainsPtr(Ptr(&x), &x), OptnilContainsPtr(Ptr(&x), &y), OptnilContainsPtr(Nil[int](), nil))
+
+	// Output:
+	// true true false false
+	// true false false
+}
+
+func ExampleOptnil_UnwrapOrZero() {
+	type A struct {
+		X int
+	}
+	var a, s, i = A{X: 1}, []int{1}, 1
+	fmt.Println(Ptr(&a).UnwrapOrZero(), Nil[A]().UnwrapOrZero())
+	fmt.Println(Ptr(&s).UnwrapOrZero(), Nil[[]int]().UnwrapOrZero() == nil)
+	fmt.Println(Ptr(&i).UnwrapOrZero(), Nil[int]().UnwrapOrZero())
+
+	// Output:
+	// {1} {0}
+	// [1] true
+	// 1 0
+}
+
+func TestOptnilJSONPointer(t *testing.T) {
+	// JSON has a single `null`, so a NonNil pointing to a nil `*int` cannot be told apart
+	// from the outer Nil: it is encoded as `null` and decodes as Nil.
+	var innerNil *int
+	var b, err = json.Marshal(Ptr(&innerNil))
+	if err != nil || string(b) != "null" {
+		t.Fatalf("marshal NonNil(nil): got %s, %v, want null", b, err)
+	}
+	var o Optnil[*int]
+	if err = json.Unmarshal(b, &o); err != nil || o.NotNil() {
+		t.Fatalf("unmarshal %s: got %v, %v, want Nil", b, o, err)
+	}
+
+	var x = 1
+	var inner = &x
+	b, err = json.Marshal(Ptr(&inner))
+	if err != nil || string(b) != "1" {
+		t.Fatalf("marshal NonNil(&1): got %s, %v, want 1", b, err)
+	}
+	if err = json.Unmarshal(b, &o); err != nil || o.IsNil() || *o.Unwrap() == nil || **o.Unwrap() != 1 {
+		t.Fatalf("unmarshal %s: got %v, %v, want NonNil(&1)", b, o, err)
+	}
+}